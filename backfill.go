@@ -0,0 +1,112 @@
+package tweetgo
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+const (
+	// maxSearchQueryLength is the longest query the standard search API accepts
+	maxSearchQueryLength = 500
+	// maxBackfillPages limits how many pages of search results are fetched per query after a reconnect
+	maxBackfillPages = 10
+	// maxBackfillRequests limits how many searches are made after a reconnect in total. The stream isn't read while
+	// backfilling, and search is limited to 180 requests every 15 minutes.
+	maxBackfillRequests = 15
+	backfillPageSize    = 100
+)
+
+// ErrBackfillIncomplete is passed to FilterStream.OnBackfillError when a backfill stopped after making as many
+// searches as it is allowed to, the tweets it found are still delivered but some missed tweets may not be
+var ErrBackfillIncomplete = errors.New("backfill stopped before every missed tweet was found")
+
+// searchBackfill will return a function which searches for every tweet matching track published after sinceID. The
+// tweets are returned oldest first.
+func (c Client) searchBackfill(track string) func(sinceID ID) ([]StatusesFilterOutput, error) {
+	queries := trackSearchQueries(track)
+
+	return func(sinceID ID) ([]StatusesFilterOutput, error) {
+		var missed []StatusesFilterOutput
+		requests := 0
+
+		for _, query := range queries {
+			input := SearchTweetsInput{
+				Q:          String(query),
+				ResultType: String("recent"),
+				Count:      Int(backfillPageSize),
+				SinceID:    IDPtr(sinceID),
+				TweetMode:  String(TweetModeExtended),
+			}
+
+			for page := 0; page < maxBackfillPages; page++ {
+				if requests == maxBackfillRequests {
+					return sortByID(missed), ErrBackfillIncomplete
+				}
+				requests++
+
+				output, err := c.SearchTweetsGet(input)
+				if err != nil {
+					return nil, err
+				}
+
+				missed = append(missed, output.Statuses...)
+
+				if len(output.Statuses) < backfillPageSize {
+					break
+				}
+
+				// results are newest first so continue below the oldest tweet on this page
+				input.MaxID = IDPtr(output.Statuses[len(output.Statuses)-1].ID - 1)
+			}
+		}
+
+		return sortByID(missed), nil
+	}
+}
+
+// sortByID will sort tweets in the order they were published
+func sortByID(tweets []StatusesFilterOutput) []StatusesFilterOutput {
+	sort.Slice(tweets, func(i, j int) bool {
+		return tweets[i].ID < tweets[j].ID
+	})
+
+	return tweets
+}
+
+// trackSearchQueries will convert a track parameter into standard search queries which find the same tweets. Words in
+// a track phrase must all match so each phrase is grouped, and the phrases are combined with OR. Queries are split up
+// when they would be too long for the search API.
+func trackSearchQueries(track string) []string {
+	var queries []string
+	query := ""
+
+	for _, phrase := range strings.Split(track, ",") {
+		words := strings.Fields(phrase)
+		if len(words) == 0 {
+			continue
+		}
+
+		term := strings.Join(words, " ")
+		if len(words) > 1 {
+			term = "(" + term + ")"
+		}
+
+		if query != "" && len(query)+len(" OR ")+len(term) > maxSearchQueryLength {
+			queries = append(queries, query)
+			query = ""
+		}
+
+		if query == "" {
+			query = term
+		} else {
+			query += " OR " + term
+		}
+	}
+
+	if query != "" {
+		queries = append(queries, query)
+	}
+
+	return queries
+}
@@ -0,0 +1,153 @@
+package tweetgo
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestTrackSearchQueriesGroupPhrases(t *testing.T) {
+	queries := trackSearchQueries("golang, hello  world,#gophercon")
+	expected := []string{"golang OR (hello world) OR #gophercon"}
+
+	if !reflect.DeepEqual(expected, queries) {
+		t.Fatalf("queries: %q != expected: %q", queries, expected)
+	}
+
+	queries = trackSearchQueries(strings.Repeat(strings.Repeat("a", 59)+",", 20))
+	if len(queries) != 3 {
+		t.Fatalf("queries: %d != expected: 3", len(queries))
+	}
+
+	for _, query := range queries {
+		if len(query) > maxSearchQueryLength {
+			t.Fatalf("query is %d bytes, longer than %d", len(query), maxSearchQueryLength)
+		}
+	}
+}
+
+// newFakeBackfillClient will create a fakeClient which serves each of streams in turn and then refuses to connect.
+// Searches return tweets 11 and 12, or a full page of results when fullPages is set.
+func newFakeBackfillClient(streams []string, fullPages bool) *fakeClient {
+	return &fakeClient{
+		respond: func(req *http.Request) (*http.Response, error) {
+			if req.URL.Host != "api.twitter.com" {
+				if len(streams) == 0 {
+					return newTestStatusResponse(http.StatusUnauthorized, ""), nil
+				}
+
+				body := streams[0]
+				streams = streams[1:]
+
+				return newTestResponse(body), nil
+			}
+
+			if !fullPages {
+				return newTestResponse(`{"statuses":[{"id":12,"text":"twelve"},{"id":11,"text":"eleven"}]}`), nil
+			}
+
+			statuses := make([]string, backfillPageSize)
+			for i := range statuses {
+				statuses[i] = `{"id":` + strconv.Itoa(1000-i) + `}`
+			}
+
+			return newTestResponse(`{"statuses":[` + strings.Join(statuses, ",") + `]}`), nil
+		},
+	}
+}
+
+// searchQueries will describe every search sent to fake
+func searchQueries(fake *fakeClient) []string {
+	var queries []string
+	for _, req := range fake.sent() {
+		if req.URL.Host == "api.twitter.com" {
+			query := req.URL.Query()
+			queries = append(queries, query.Get("q")+" since:"+query.Get("since_id")+" mode:"+query.Get("tweet_mode"))
+		}
+	}
+
+	return queries
+}
+
+func TestFilterStreamBackfillsTweetsMissedWhileReconnecting(t *testing.T) {
+	fake := newFakeBackfillClient([]string{
+		"{\"id\":10,\"text\":\"ten\"}\r\n",
+		"{\"id\":12,\"text\":\"twelve\"}\r\n{\"id\":13,\"text\":\"thirteen\"}\r\n",
+	}, false)
+
+	tc := NewClient("key", "secret")
+	tc.HTTPClient = fake
+
+	fs, err := tc.StatusesFilterStream(StatusesFilterInput{Track: String("golang")})
+	if err != nil {
+		t.Fatalf("StatusesFilterStream failed: %s", err.Error())
+	}
+	fs.Reconnect = true
+	fs.Backfill = true
+
+	var delivered []string
+	fs.Run(func(output StatusesFilterOutput) {
+		if output.Backfilled {
+			delivered = append(delivered, output.Text+" (backfilled)")
+		} else {
+			delivered = append(delivered, output.Text)
+		}
+	})
+
+	expected := []string{"ten", "eleven (backfilled)", "twelve (backfilled)", "thirteen"}
+	if !reflect.DeepEqual(expected, delivered) {
+		t.Fatalf("delivered: %q != expected: %q", delivered, expected)
+	}
+
+	queries := searchQueries(fake)
+	if len(queries) != 1 || queries[0] != "golang since:10 mode:extended" {
+		t.Fatalf("unexpected searches: %q", queries)
+	}
+
+	if lastID, _ := fs.LastTweet(); lastID != 13 {
+		t.Fatalf("last tweet: %d != expected: 13", lastID)
+	}
+}
+
+func TestFilterStreamLimitsBackfillSearches(t *testing.T) {
+	fake := newFakeBackfillClient([]string{
+		"{\"id\":10,\"text\":\"ten\"}\r\n",
+		"{\"id\":2000,\"text\":\"later\"}\r\n",
+	}, true)
+
+	tc := NewClient("key", "secret")
+	tc.HTTPClient = fake
+
+	fs, err := tc.StatusesFilterStream(StatusesFilterInput{Track: String(strings.Join(testPhrases("phrase", 400), ","))})
+	if err != nil {
+		t.Fatalf("StatusesFilterStream failed: %s", err.Error())
+	}
+	fs.Reconnect = true
+	fs.Backfill = true
+
+	var backfillErr error
+	fs.OnBackfillError = func(err error) {
+		backfillErr = err
+	}
+
+	backfilled := 0
+	fs.Run(func(output StatusesFilterOutput) {
+		if output.Backfilled {
+			backfilled++
+		}
+	})
+
+	if len(searchQueries(fake)) != maxBackfillRequests {
+		t.Fatalf("searches: %d != expected: %d", len(searchQueries(fake)), maxBackfillRequests)
+	}
+
+	if backfillErr != ErrBackfillIncomplete {
+		t.Fatalf("backfill error: %v != expected: %v", backfillErr, ErrBackfillIncomplete)
+	}
+
+	if backfilled == 0 {
+		t.Fatalf("the tweets found before the limit was reached weren't delivered")
+	}
+}
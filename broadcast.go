@@ -0,0 +1,215 @@
+package tweetgo
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// BackpressurePolicy decides what a StreamBroadcaster does when a subscriber's buffer is full
+type BackpressurePolicy int
+
+const (
+	// BackpressureBlock waits until the subscriber has room, which holds up every other subscriber and eventually the
+	// connection itself
+	BackpressureBlock BackpressurePolicy = iota
+	// BackpressureDropOldest discards the oldest buffered tweet to make room for the new one
+	BackpressureDropOldest
+	// BackpressureDisconnect closes the subscription as soon as it falls behind
+	BackpressureDisconnect
+)
+
+// StreamBroadcaster delivers every tweet from a single stream to any number of subscribers. Twitter limits the number
+// of concurrent streams so this allows several consumers to share one connection.
+type StreamBroadcaster struct {
+	stream *FilterStream
+
+	stop     chan struct{}
+	stopOnce sync.Once
+
+	mu          sync.Mutex
+	subscribers map[*Subscription]struct{}
+	done        bool
+	dropped     uint64
+}
+
+// Subscription receives the tweets delivered by a StreamBroadcaster
+type Subscription struct {
+	broadcaster *StreamBroadcaster
+	policy      BackpressurePolicy
+	messages    chan StatusesFilterOutput
+
+	closeOnce    sync.Once
+	closed       chan struct{}
+	dropped      uint64
+	disconnected int32
+}
+
+// NewStreamBroadcaster will create a StreamBroadcaster reading from fs. Nothing is read until Run is called.
+func NewStreamBroadcaster(fs *FilterStream) *StreamBroadcaster {
+	return &StreamBroadcaster{
+		stream:      fs,
+		stop:        make(chan struct{}),
+		subscribers: map[*Subscription]struct{}{},
+	}
+}
+
+// Subscribe will add a subscriber with room for buffer tweets which handles falling behind according to policy.
+// BackpressureDropOldest always buffers at least one tweet.
+func (b *StreamBroadcaster) Subscribe(buffer int, policy BackpressurePolicy) *Subscription {
+	if policy == BackpressureDropOldest && buffer < 1 {
+		buffer = 1
+	}
+
+	sub := &Subscription{
+		broadcaster: b,
+		policy:      policy,
+		messages:    make(chan StatusesFilterOutput, buffer),
+		closed:      make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.done {
+		close(sub.messages)
+		return sub
+	}
+
+	b.subscribers[sub] = struct{}{}
+
+	return sub
+}
+
+// Run will read the stream and deliver every tweet to the subscribers until the stream ends. When Run returns every
+// subscription has been closed. The error is the same as FilterStream.Run.
+func (b *StreamBroadcaster) Run() error {
+	err := b.stream.Run(b.broadcast)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.done = true
+	for sub := range b.subscribers {
+		b.remove(sub)
+	}
+
+	return err
+}
+
+// Stop will stop the underlying stream and stop waiting for subscribers that have fallen behind, which ends Run. It
+// is safe to call Stop more than once.
+func (b *StreamBroadcaster) Stop() {
+	b.stopOnce.Do(func() {
+		close(b.stop)
+	})
+
+	b.stream.Stop()
+}
+
+// Dropped will return the total number of tweets dropped across every subscriber, including closed ones
+func (b *StreamBroadcaster) Dropped() uint64 {
+	return atomic.LoadUint64(&b.dropped)
+}
+
+func (b *StreamBroadcaster) broadcast(output StatusesFilterOutput) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscribers {
+		if !sub.deliver(output) {
+			b.remove(sub)
+		}
+	}
+}
+
+// remove must be called with mu held
+func (b *StreamBroadcaster) remove(sub *Subscription) {
+	delete(b.subscribers, sub)
+	close(sub.messages)
+}
+
+// deliver will return false when the subscription should be removed, or the broadcaster was stopped
+func (s *Subscription) deliver(output StatusesFilterOutput) bool {
+	stop := s.broadcaster.stop
+
+	select {
+	case <-s.closed:
+		return false
+	case <-stop:
+		return false
+	default:
+	}
+
+	switch s.policy {
+	case BackpressureDropOldest:
+		for {
+			select {
+			case s.messages <- output:
+				return true
+			case <-stop:
+				return false
+			default:
+			}
+
+			select {
+			case <-s.messages:
+				s.drop()
+			default:
+			}
+		}
+	case BackpressureDisconnect:
+		select {
+		case s.messages <- output:
+			return true
+		default:
+			s.drop()
+			atomic.StoreInt32(&s.disconnected, 1)
+			return false
+		}
+	default:
+		select {
+		case s.messages <- output:
+			return true
+		case <-s.closed:
+			return false
+		case <-stop:
+			return false
+		}
+	}
+}
+
+func (s *Subscription) drop() {
+	atomic.AddUint64(&s.dropped, 1)
+	atomic.AddUint64(&s.broadcaster.dropped, 1)
+}
+
+// Messages will return the channel of tweets for this subscriber. It is closed when the subscription is closed, the
+// subscriber is disconnected for falling behind or the stream ends.
+func (s *Subscription) Messages() <-chan StatusesFilterOutput {
+	return s.messages
+}
+
+// Dropped will return the number of tweets this subscriber missed because it fell behind
+func (s *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Disconnected will return true if the subscription was closed because it fell behind with BackpressureDisconnect
+func (s *Subscription) Disconnected() bool {
+	return atomic.LoadInt32(&s.disconnected) == 1
+}
+
+// Close will unsubscribe from the broadcaster. Any tweets still buffered can be read from Messages.
+func (s *Subscription) Close() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+	})
+
+	b := s.broadcaster
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[s]; ok {
+		b.remove(s)
+	}
+}
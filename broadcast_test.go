@@ -0,0 +1,91 @@
+package tweetgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStreamBroadcasterAppliesBackpressurePolicies(t *testing.T) {
+	body := "{\"id\":1}\r\n{\"id\":2}\r\n{\"id\":3}\r\n{\"id\":4}\r\n{\"id\":5}\r\n"
+	b := NewStreamBroadcaster(NewFilterStream(newTestResponse(body), false))
+
+	blocking := b.Subscribe(0, BackpressureBlock)
+	dropOldest := b.Subscribe(1, BackpressureDropOldest)
+	disconnect := b.Subscribe(1, BackpressureDisconnect)
+
+	done := make(chan []ID)
+	go func() {
+		var ids []ID
+		for output := range blocking.Messages() {
+			ids = append(ids, output.ID)
+		}
+		done <- ids
+	}()
+
+	b.Run()
+
+	if ids := <-done; len(ids) != 5 {
+		t.Fatalf("blocking subscriber ids: %v != expected: [1 2 3 4 5]", ids)
+	}
+
+	var ids []ID
+	for output := range dropOldest.Messages() {
+		ids = append(ids, output.ID)
+	}
+	if len(ids) != 1 || ids[0] != 5 || dropOldest.Dropped() != 4 {
+		t.Fatalf("drop oldest subscriber ids: %v dropped: %d, expected: [5] dropped: 4", ids, dropOldest.Dropped())
+	}
+
+	ids = nil
+	for output := range disconnect.Messages() {
+		ids = append(ids, output.ID)
+	}
+	if len(ids) != 1 || ids[0] != 1 || !disconnect.Disconnected() || disconnect.Dropped() != 1 {
+		t.Fatalf("disconnect subscriber ids: %v dropped: %d disconnected: %t", ids, disconnect.Dropped(), disconnect.Disconnected())
+	}
+
+	if b.Dropped() != 5 {
+		t.Fatalf("total dropped: %d != expected: 5", b.Dropped())
+	}
+}
+
+func TestSubscriptionCloseUnblocksBroadcaster(t *testing.T) {
+	body := "{\"id\":1}\r\n{\"id\":2}\r\n"
+	b := NewStreamBroadcaster(NewFilterStream(newTestResponse(body), false))
+
+	stuck := b.Subscribe(0, BackpressureBlock)
+	go stuck.Close()
+
+	b.Run()
+
+	// the channel must be closed once the subscription is removed, otherwise this would never finish
+	for range stuck.Messages() {
+	}
+}
+
+func TestStreamBroadcasterStopEndsRunWhileASubscriberIsStalled(t *testing.T) {
+	body := "{\"id\":1}\r\n{\"id\":2}\r\n"
+	b := NewStreamBroadcaster(NewFilterStream(newTestResponse(body), false))
+
+	stalled := b.Subscribe(0, BackpressureBlock)
+
+	done := make(chan error)
+	go func() {
+		done <- b.Run()
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	b.Stop()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("expected nil after Stop, got: %s", err.Error())
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Stop didn't end Run while a subscriber was stalled")
+	}
+
+	for range stalled.Messages() {
+	}
+}
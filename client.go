@@ -1,8 +1,7 @@
 package tweetgo
 
 import (
-	"encoding/json"
-	"io/ioutil"
+	"context"
 	"math/rand"
 	"net/http"
 	"time"
@@ -19,6 +18,10 @@ type Client struct {
 	HTTPClient             requestMaker
 	Noncer                 nonceMaker
 	Timer                  currentTimer
+	// OnResponse is called with the status, headers, timing and raw body of every request once its body is closed
+	OnResponse func(Response)
+	// Middleware wraps the sending of every request, the first middleware sees the request first
+	Middleware []Middleware
 }
 
 type noncer struct{}
@@ -121,13 +124,8 @@ func (c Client) ListsListGet(input ListsListInput) ([]ListsListOutput, error) {
 	}
 	defer res.Body.Close()
 
-	resBytes, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return []ListsListOutput{}, err
-	}
-
 	output := []ListsListOutput{}
-	err = json.Unmarshal(resBytes, &output)
+	err = decodeBody(res.Body, &output)
 	if err != nil {
 		return []ListsListOutput{}, err
 	}
@@ -148,13 +146,8 @@ func (c Client) ListsMembersGet(input ListsMembersInput) (ListsMembersOutput, er
 	}
 	defer res.Body.Close()
 
-	resBytes, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return ListsMembersOutput{}, err
-	}
-
 	output := ListsMembersOutput{}
-	err = json.Unmarshal(resBytes, &output)
+	err = decodeBody(res.Body, &output)
 	if err != nil {
 		return ListsMembersOutput{}, err
 	}
@@ -174,13 +167,8 @@ func (c Client) ListsMembersShowGet(input ListsMembersShowInput) (ListsMembersSh
 	}
 	defer res.Body.Close()
 
-	resBytes, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return ListsMembersShowOutput{}, err
-	}
-
 	output := ListsMembersShowOutput{}
-	err = json.Unmarshal(resBytes, &output)
+	err = decodeBody(res.Body, &output)
 	if err != nil {
 		return ListsMembersShowOutput{}, err
 	}
@@ -200,13 +188,8 @@ func (c Client) StatusesUpdatePost(input StatusesUpdateInput) (StatusesUpdateOut
 	}
 	defer res.Body.Close()
 
-	resBytes, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return StatusesUpdateOutput{}, err
-	}
-
 	output := StatusesUpdateOutput{}
-	err = json.Unmarshal(resBytes, &output)
+	err = decodeBody(res.Body, &output)
 	if err != nil {
 		return StatusesUpdateOutput{}, err
 	}
@@ -217,10 +200,54 @@ func (c Client) StatusesUpdatePost(input StatusesUpdateInput) (StatusesUpdateOut
 // StatusesFilterPostRaw will get a streaming list of tweets and return the raw http response for streaming
 // https://developer.twitter.com/en/docs/tweets/filter-realtime/api-reference/post-statuses-filter
 func (c Client) StatusesFilterPostRaw(input StatusesFilterInput) (*http.Response, error) {
+	return c.statusesFilterPost(context.Background(), input)
+}
+
+func (c Client) statusesFilterPost(ctx context.Context, input StatusesFilterInput) (*http.Response, error) {
 	uri := "https://stream.twitter.com/1.1/statuses/filter.json"
 	params := processParams(input)
 
-	res, err := c.executeRequest(http.MethodPost, uri, params)
+	res, err := c.executeStreamRequest(ctx, http.MethodPost, uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// StatusesFilterStream will connect to statuses/filter and return a FilterStream which decodes the streamed tweets.
+// Set Reconnect on the returned stream to have it reconnect when the connection drops.
+// https://developer.twitter.com/en/docs/tweets/filter-realtime/api-reference/post-statuses-filter
+func (c Client) StatusesFilterStream(input StatusesFilterInput) (*FilterStream, error) {
+	connect := func(ctx context.Context) (*http.Response, error) {
+		return c.statusesFilterPost(ctx, input)
+	}
+
+	delimited := input.Delimited != nil && *input.Delimited == "length"
+
+	fs, err := newReconnectingFilterStream(connect, delimited)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Track != nil {
+		fs.search = c.searchBackfill(*input.Track)
+	}
+
+	return fs, nil
+}
+
+// StatusesSampleGetRaw will get a streaming sample of all public tweets and return the raw http response for streaming
+// https://developer.twitter.com/en/docs/tweets/sample-realtime/api-reference/get-statuses-sample
+func (c Client) StatusesSampleGetRaw(input StatusesSampleInput) (*http.Response, error) {
+	return c.statusesSampleGet(context.Background(), input)
+}
+
+func (c Client) statusesSampleGet(ctx context.Context, input StatusesSampleInput) (*http.Response, error) {
+	uri := "https://stream.twitter.com/1.1/statuses/sample.json"
+	params := processParams(input)
+
+	res, err := c.executeStreamRequest(ctx, http.MethodGet, uri, params)
 	if err != nil {
 		return nil, err
 	}
@@ -228,6 +255,19 @@ func (c Client) StatusesFilterPostRaw(input StatusesFilterInput) (*http.Response
 	return res, nil
 }
 
+// StatusesSampleStream will connect to statuses/sample and return a FilterStream which decodes the streamed tweets.
+// Sample messages have the same format as filter messages so they are read the same way.
+// https://developer.twitter.com/en/docs/tweets/sample-realtime/api-reference/get-statuses-sample
+func (c Client) StatusesSampleStream(input StatusesSampleInput) (*FilterStream, error) {
+	connect := func(ctx context.Context) (*http.Response, error) {
+		return c.statusesSampleGet(ctx, input)
+	}
+
+	delimited := input.Delimited != nil && *input.Delimited == "length"
+
+	return newReconnectingFilterStream(connect, delimited)
+}
+
 // StatusesUserTimelineGet will get a users timeline and return an array of tweets
 // https://developer.twitter.com/en/docs/tweets/timelines/api-reference/get-statuses-user_timeline
 func (c Client) StatusesUserTimelineGet(input StatusesUserTimelineInput) ([]StatusesUserTimelineOutput, error) {
@@ -240,15 +280,31 @@ func (c Client) StatusesUserTimelineGet(input StatusesUserTimelineInput) ([]Stat
 	}
 	defer res.Body.Close()
 
-	resBytes, err := ioutil.ReadAll(res.Body)
+	var output []StatusesUserTimelineOutput
+	err = decodeBody(res.Body, &output)
 	if err != nil {
 		return []StatusesUserTimelineOutput{}, err
 	}
 
-	var output []StatusesUserTimelineOutput
-	err = json.Unmarshal(resBytes, &output)
+	return output, nil
+}
+
+// SearchTweetsGet will search the tweets published in the past 7 days
+// https://developer.twitter.com/en/docs/tweets/search/api-reference/get-search-tweets
+func (c Client) SearchTweetsGet(input SearchTweetsInput) (SearchTweetsOutput, error) {
+	uri := "https://api.twitter.com/1.1/search/tweets.json"
+	params := processParams(input)
+
+	res, err := c.executeRequest(http.MethodGet, uri, params)
 	if err != nil {
-		return []StatusesUserTimelineOutput{}, err
+		return SearchTweetsOutput{}, err
+	}
+	defer res.Body.Close()
+
+	output := SearchTweetsOutput{}
+	err = decodeBody(res.Body, &output)
+	if err != nil {
+		return SearchTweetsOutput{}, err
 	}
 
 	return output, nil
@@ -0,0 +1,127 @@
+package tweetgo
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Limits twitter places on the parameters of a single statuses/filter connection
+// https://developer.twitter.com/en/docs/tweets/filter-realtime/guides/basic-stream-parameters
+const (
+	MaxTrackKeywords    = 400
+	MaxTrackPhraseBytes = 60
+	MaxFollowUserIDs    = 5000
+	MaxLocationBoxes    = 25
+)
+
+// BoundingBox is a rectangular area given by the longitude and latitude of its south west and north east corners
+type BoundingBox struct {
+	SouthWestLong float64
+	SouthWestLat  float64
+	NorthEastLong float64
+	NorthEastLat  float64
+}
+
+// FilterTrack will encode keyword phrases for StatusesFilterInput.Track. Words within a phrase separated by spaces
+// must all appear in a tweet for it to match, any one of the phrases matching is enough.
+func FilterTrack(phrases ...string) (*string, error) {
+	if len(phrases) == 0 {
+		return nil, errors.New("track requires at least one phrase")
+	}
+
+	if len(phrases) > MaxTrackKeywords {
+		return nil, errors.New("track is limited to " + strconv.Itoa(MaxTrackKeywords) + " phrases, got " +
+			strconv.Itoa(len(phrases)))
+	}
+
+	encoded := make([]string, len(phrases))
+	for i, phrase := range phrases {
+		phrase = strings.Join(strings.Fields(phrase), " ")
+
+		if phrase == "" {
+			return nil, errors.New("track phrases can't be empty")
+		}
+
+		if strings.Contains(phrase, ",") {
+			return nil, errors.New("track phrase \"" + phrase + "\" can't contain a comma")
+		}
+
+		if len(phrase) > MaxTrackPhraseBytes {
+			return nil, errors.New("track phrase \"" + phrase + "\" is longer than " +
+				strconv.Itoa(MaxTrackPhraseBytes) + " bytes")
+		}
+
+		encoded[i] = phrase
+	}
+
+	return String(strings.Join(encoded, ",")), nil
+}
+
+// FilterFollow will encode user ids for StatusesFilterInput.Follow
+func FilterFollow(userIDs ...ID) (*string, error) {
+	if len(userIDs) == 0 {
+		return nil, errors.New("follow requires at least one user id")
+	}
+
+	if len(userIDs) > MaxFollowUserIDs {
+		return nil, errors.New("follow is limited to " + strconv.Itoa(MaxFollowUserIDs) + " user ids, got " +
+			strconv.Itoa(len(userIDs)))
+	}
+
+	encoded := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		if userID <= 0 {
+			return nil, errors.New("follow user id " + userID.String() + " is invalid")
+		}
+
+		encoded[i] = userID.String()
+	}
+
+	return String(strings.Join(encoded, ",")), nil
+}
+
+// FilterLocations will encode bounding boxes for StatusesFilterInput.Locations
+func FilterLocations(boxes ...BoundingBox) (*string, error) {
+	if len(boxes) == 0 {
+		return nil, errors.New("locations requires at least one bounding box")
+	}
+
+	if len(boxes) > MaxLocationBoxes {
+		return nil, errors.New("locations is limited to " + strconv.Itoa(MaxLocationBoxes) + " bounding boxes, got " +
+			strconv.Itoa(len(boxes)))
+	}
+
+	encoded := make([]string, 0, len(boxes)*4)
+	for _, box := range boxes {
+		err := box.validate()
+		if err != nil {
+			return nil, err
+		}
+
+		encoded = append(encoded,
+			strconv.FormatFloat(box.SouthWestLong, 'f', -1, 64),
+			strconv.FormatFloat(box.SouthWestLat, 'f', -1, 64),
+			strconv.FormatFloat(box.NorthEastLong, 'f', -1, 64),
+			strconv.FormatFloat(box.NorthEastLat, 'f', -1, 64),
+		)
+	}
+
+	return String(strings.Join(encoded, ",")), nil
+}
+
+func (b BoundingBox) validate() error {
+	if b.SouthWestLong < -180 || b.SouthWestLong > 180 || b.NorthEastLong < -180 || b.NorthEastLong > 180 {
+		return errors.New("bounding box longitudes must be between -180 and 180")
+	}
+
+	if b.SouthWestLat < -90 || b.SouthWestLat > 90 || b.NorthEastLat < -90 || b.NorthEastLat > 90 {
+		return errors.New("bounding box latitudes must be between -90 and 90")
+	}
+
+	if b.SouthWestLong >= b.NorthEastLong || b.SouthWestLat >= b.NorthEastLat {
+		return errors.New("bounding box south west corner must be below and to the left of the north east corner")
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package tweetgo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFilterTrackEncodesPhrases(t *testing.T) {
+	track, err := FilterTrack("golang", "  twitter   api ", "#gophercon")
+	if err != nil {
+		t.Fatalf("FilterTrack failed: %s", err.Error())
+	}
+
+	expected := "golang,twitter api,#gophercon"
+	if *track != expected {
+		t.Fatalf("track: %s != expected: %s", *track, expected)
+	}
+}
+
+func TestFilterTrackEnforcesLimits(t *testing.T) {
+	tooMany := make([]string, MaxTrackKeywords+1)
+	for i := range tooMany {
+		tooMany[i] = "keyword"
+	}
+
+	for name, phrases := range map[string][]string{
+		"no phrases":   {},
+		"empty phrase": {"golang", " "},
+		"comma":        {"go,lang"},
+		"too long":     {strings.Repeat("a", MaxTrackPhraseBytes+1)},
+		"too many":     tooMany,
+	} {
+		if _, err := FilterTrack(phrases...); err == nil {
+			t.Fatalf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestFilterFollowEncodesUserIDs(t *testing.T) {
+	follow, err := FilterFollow(12, 783214)
+	if err != nil {
+		t.Fatalf("FilterFollow failed: %s", err.Error())
+	}
+
+	if *follow != "12,783214" {
+		t.Fatalf("follow: %s != expected: 12,783214", *follow)
+	}
+
+	if _, err := FilterFollow(make([]ID, MaxFollowUserIDs+1)...); err == nil {
+		t.Fatalf("expected an error for too many user ids")
+	}
+}
+
+func TestFilterLocationsEncodesBoundingBoxes(t *testing.T) {
+	locations, err := FilterLocations(
+		BoundingBox{SouthWestLong: -122.75, SouthWestLat: 36.8, NorthEastLong: -121.75, NorthEastLat: 37.8},
+		BoundingBox{SouthWestLong: -74, SouthWestLat: 40, NorthEastLong: -73, NorthEastLat: 41},
+	)
+	if err != nil {
+		t.Fatalf("FilterLocations failed: %s", err.Error())
+	}
+
+	expected := "-122.75,36.8,-121.75,37.8,-74,40,-73,41"
+	if *locations != expected {
+		t.Fatalf("locations: %s != expected: %s", *locations, expected)
+	}
+
+	_, err = FilterLocations(BoundingBox{SouthWestLong: -73, SouthWestLat: 41, NorthEastLong: -74, NorthEastLat: 40})
+	if err == nil {
+		t.Fatalf("expected an error for corners in the wrong order")
+	}
+
+	_, err = FilterLocations(make([]BoundingBox, MaxLocationBoxes+1)...)
+	if err == nil {
+		t.Fatalf("expected an error for too many bounding boxes")
+	}
+}
@@ -0,0 +1,85 @@
+package tweetgo
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// decodeTestOutput will decode raw as a streamed tweet, failing the test if it isn't valid
+func decodeTestOutput(t *testing.T, raw string) StatusesFilterOutput {
+	output := StatusesFilterOutput{}
+	err := json.Unmarshal([]byte(raw), &output)
+	if err != nil {
+		t.Fatalf("invalid test tweet: %s", err.Error())
+	}
+
+	return output
+}
+
+// readTestFixture will return the contents of a file in testdata
+func readTestFixture(t *testing.T, name string) string {
+	raw, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("could not read fixture %s: %s", name, err.Error())
+	}
+
+	return string(raw)
+}
+
+func newTestStatusResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Status:     strconv.Itoa(statusCode) + " " + http.StatusText(statusCode),
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
+
+// fakeClient is the HTTPClient used by tests. It records every request and answers it with respond.
+type fakeClient struct {
+	respond func(req *http.Request) (*http.Response, error)
+
+	mu       sync.Mutex
+	requests []*http.Request
+}
+
+// newFakeClient will create a fakeClient which answers every request with statusCode and body
+func newFakeClient(statusCode int, body string) *fakeClient {
+	return &fakeClient{
+		respond: func(req *http.Request) (*http.Response, error) {
+			return newTestStatusResponse(statusCode, body), nil
+		},
+	}
+}
+
+// newFailingClient will create a fakeClient for tests where no request should be sent
+func newFailingClient() *fakeClient {
+	return &fakeClient{
+		respond: func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("the request should not have been sent")
+		},
+	}
+}
+
+func (f *fakeClient) Do(req *http.Request) (*http.Response, error) {
+	f.mu.Lock()
+	f.requests = append(f.requests, req)
+	f.mu.Unlock()
+
+	return f.respond(req)
+}
+
+// sent will return the requests made so far
+func (f *fakeClient) sent() []*http.Request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]*http.Request(nil), f.requests...)
+}
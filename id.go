@@ -0,0 +1,62 @@
+package tweetgo
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// ID is the id of a tweet, user, list or any other twitter object. It decodes from either a JSON number or a string,
+// so it can be read from v1.1 ids, id_str values and v2 responses without losing precision, and is always encoded as a
+// string so it is safe to pass to JavaScript.
+// https://developer.twitter.com/en/docs/basics/twitter-ids
+type ID int64
+
+// ParseID will parse the decimal string form of an id
+func ParseID(s string) (ID, error) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid id: " + s)
+	}
+
+	return ID(id), nil
+}
+
+// String will return the decimal form of the id, the same as id_str
+func (id ID) String() string {
+	return strconv.FormatInt(int64(id), 10)
+}
+
+// UnmarshalJSON will decode an id from a JSON number or string. Null and empty strings decode to 0.
+func (id *ID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*id = 0
+		return nil
+	}
+
+	value := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		err := json.Unmarshal(data, &value)
+		if err != nil {
+			return err
+		}
+
+		if value == "" {
+			*id = 0
+			return nil
+		}
+	}
+
+	parsed, err := ParseID(value)
+	if err != nil {
+		return err
+	}
+
+	*id = parsed
+	return nil
+}
+
+// MarshalJSON will encode the id as a JSON string
+func (id ID) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + id.String() + `"`), nil
+}
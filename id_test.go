@@ -0,0 +1,69 @@
+package tweetgo
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestIDDecodesNumbersAndStrings(t *testing.T) {
+	var output struct {
+		Number  ID   `json:"number"`
+		String  ID   `json:"string"`
+		Null    ID   `json:"null"`
+		Empty   ID   `json:"empty"`
+		Strings []ID `json:"strings"`
+	}
+
+	err := json.Unmarshal([]byte(`{
+		"number": 1050118621198921728,
+		"string": "1050118621198921729",
+		"null": null,
+		"empty": "",
+		"strings": ["1", "2"]
+	}`), &output)
+	if err != nil {
+		t.Fatalf("Unmarshal failed: %s", err.Error())
+	}
+
+	if output.Number != 1050118621198921728 || output.String != 1050118621198921729 {
+		t.Fatalf("number: %s, string: %s != expected: 1050118621198921728, 1050118621198921729", output.Number, output.String)
+	}
+
+	if output.Null != 0 || output.Empty != 0 || !reflect.DeepEqual(output.Strings, []ID{1, 2}) {
+		t.Fatalf("unexpected ids: %+v", output)
+	}
+
+	encoded, err := json.Marshal(output)
+	if err != nil {
+		t.Fatalf("Marshal failed: %s", err.Error())
+	}
+
+	expected := `{"number":"1050118621198921728","string":"1050118621198921729","null":"0","empty":"0","strings":["1","2"]}`
+	if string(encoded) != expected {
+		t.Fatalf("encoded: %s != expected: %s", encoded, expected)
+	}
+}
+
+func TestIDRejectsInvalidValues(t *testing.T) {
+	for _, raw := range []string{`"abc"`, `1.5`, `true`, `"99999999999999999999"`} {
+		var id ID
+		if err := json.Unmarshal([]byte(raw), &id); err == nil {
+			t.Errorf("expected an error decoding %s", raw)
+		}
+	}
+}
+
+func TestProcessParamsEncodesIDs(t *testing.T) {
+	o := processParams(StatusesUserTimelineInput{UserID: IDPtr(1050118621198921728), SinceID: IDPtr(0)})
+
+	expected := url.Values{
+		"user_id":  {"1050118621198921728"},
+		"since_id": {"0"},
+	}
+
+	if !reflect.DeepEqual(expected, o) {
+		t.Fatalf("params: %v != expected: %v", o, expected)
+	}
+}
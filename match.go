@@ -0,0 +1,363 @@
+package tweetgo
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// FilterMatcher decides locally whether a tweet would be delivered by statuses/filter for a given set of track, follow
+// and locations parameters. It can be used to re-filter recorded streams, try out rule changes offline or to split a
+// single connection into several tagged streams.
+// https://developer.twitter.com/en/docs/tweets/filter-realtime/guides/basic-stream-parameters
+type FilterMatcher struct {
+	track     []trackPhrase
+	follow    map[ID]struct{}
+	locations []BoundingBox
+}
+
+type trackPhrase struct {
+	phrase string
+	words  []string
+}
+
+// NewFilterMatcher will create a FilterMatcher from the same input used to open a filter stream. Only Track, Follow
+// and Locations are used.
+func NewFilterMatcher(input StatusesFilterInput) (*FilterMatcher, error) {
+	m := &FilterMatcher{
+		follow: map[ID]struct{}{},
+	}
+
+	if input.Track != nil {
+		for _, phrase := range strings.Split(*input.Track, ",") {
+			words := strings.Fields(strings.ToLower(phrase))
+			if len(words) == 0 {
+				continue
+			}
+
+			m.track = append(m.track, trackPhrase{
+				phrase: strings.Join(words, " "),
+				words:  words,
+			})
+		}
+	}
+
+	if input.Follow != nil {
+		for _, userID := range strings.Split(*input.Follow, ",") {
+			userID = strings.TrimSpace(userID)
+			if userID == "" {
+				continue
+			}
+
+			id, err := ParseID(userID)
+			if err != nil {
+				return nil, errors.New("invalid follow user id: " + userID)
+			}
+
+			m.follow[id] = struct{}{}
+		}
+	}
+
+	if input.Locations != nil && strings.TrimSpace(*input.Locations) != "" {
+		values := strings.Split(*input.Locations, ",")
+		if len(values)%4 != 0 {
+			return nil, errors.New("locations must contain four values for every bounding box")
+		}
+
+		coords := make([]float64, len(values))
+		for i, value := range values {
+			coord, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				return nil, errors.New("invalid locations value: " + value)
+			}
+
+			coords[i] = coord
+		}
+
+		for i := 0; i < len(coords); i += 4 {
+			m.locations = append(m.locations, BoundingBox{
+				SouthWestLong: coords[i],
+				SouthWestLat:  coords[i+1],
+				NorthEastLong: coords[i+2],
+				NorthEastLat:  coords[i+3],
+			})
+		}
+	}
+
+	return m, nil
+}
+
+// Match will report whether the stream would deliver output. Like statuses/filter, retweets and quotes also match on
+// the tweet they retweet or quote.
+func (m *FilterMatcher) Match(output StatusesFilterOutput) bool {
+	if m.matchFollow(output) {
+		return true
+	}
+
+	if m.MatchLocations(output.Tweet) {
+		return true
+	}
+
+	return len(m.matchedTrack(m.outputTerms(output))) > 0
+}
+
+// MatchTweet will report whether the stream would deliver a single tweet, without considering anything it retweets
+// or quotes
+func (m *FilterMatcher) MatchTweet(t Tweet) bool {
+	if m.followed(t.User.ID) || m.followed(t.InReplyToUserID) {
+		return true
+	}
+
+	if m.MatchLocations(t) {
+		return true
+	}
+
+	return len(m.matchedTrack(tweetTerms(t))) > 0
+}
+
+// MatchedTrack will return every track phrase that output matches, which is useful for tagging tweets with the rules
+// that caused them to be delivered
+func (m *FilterMatcher) MatchedTrack(output StatusesFilterOutput) []string {
+	return m.matchedTrack(m.outputTerms(output))
+}
+
+// MatchLocations will report whether a tweet was sent from within one of the bounding boxes. Exact coordinates are
+// used when the tweet has them, otherwise the tweet matches when its place overlaps a bounding box.
+func (m *FilterMatcher) MatchLocations(t Tweet) bool {
+	if len(m.locations) == 0 {
+		return false
+	}
+
+	if len(t.Coordinates.Coordinates) == 2 {
+		long, lat := t.Coordinates.Coordinates[0], t.Coordinates.Coordinates[1]
+		for _, box := range m.locations {
+			if box.contains(long, lat) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	placeBox, ok := t.Place.BoundingBox.extent()
+	if !ok {
+		return false
+	}
+
+	for _, box := range m.locations {
+		if box.intersects(placeBox) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *FilterMatcher) followed(userID ID) bool {
+	if userID == 0 {
+		return false
+	}
+
+	_, ok := m.follow[userID]
+	return ok
+}
+
+// matchFollow matches tweets created by, retweeted by or replying to a followed user, and retweets of their tweets
+func (m *FilterMatcher) matchFollow(output StatusesFilterOutput) bool {
+	return m.followed(output.User.ID) ||
+		m.followed(output.InReplyToUserID) ||
+		m.followed(output.RetweetedStatus.User.ID)
+}
+
+func (m *FilterMatcher) outputTerms(output StatusesFilterOutput) map[string]struct{} {
+	terms := tweetTerms(output.Tweet)
+	for _, t := range []Tweet{output.RetweetedStatus, output.QuotedStatus} {
+		for term := range tweetTerms(t) {
+			terms[term] = struct{}{}
+		}
+	}
+
+	return terms
+}
+
+func (m *FilterMatcher) matchedTrack(terms map[string]struct{}) []string {
+	var matched []string
+
+	for _, phrase := range m.track {
+		all := true
+		for _, word := range phrase.words {
+			if _, ok := terms[word]; !ok {
+				all = false
+				break
+			}
+		}
+
+		if all {
+			matched = append(matched, phrase.phrase)
+		}
+	}
+
+	return matched
+}
+
+// tweetTerms will return every lowercased term a track word can match in a tweet, using the untruncated text and
+// entities of extended tweets. Words in the text match with or without surrounding punctuation, hashtags, mentions and
+// cashtags match with or without their prefix, and links are matched on their expanded and display urls rather than
+// the t.co link in the text.
+func tweetTerms(t Tweet) map[string]struct{} {
+	terms := map[string]struct{}{}
+	add := func(term string) {
+		if term != "" {
+			terms[term] = struct{}{}
+		}
+	}
+
+	for _, token := range strings.Fields(strings.ToLower(tweetText(t))) {
+		if strings.HasPrefix(token, "http://") || strings.HasPrefix(token, "https://") {
+			continue
+		}
+
+		add(token)
+
+		trimmed := strings.TrimRightFunc(token, isTrackPunctuation)
+		if strings.HasPrefix(trimmed, "@") {
+			name := strings.TrimLeft(trimmed, "@")
+			if i := strings.IndexFunc(name, func(r rune) bool { return !isScreenNameRune(r) }); i >= 0 {
+				name = name[:i]
+			}
+
+			add(name)
+			add("@" + name)
+			continue
+		}
+
+		core := strings.TrimFunc(token, isTrackPunctuation)
+		add(core)
+
+		if strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "$") {
+			add(trimmed[:1] + core)
+		}
+	}
+
+	for _, entities := range []Entities{t.Entities, t.ExtendedTweet.Entities} {
+		for _, hashtag := range entities.Hashtags {
+			add(strings.ToLower(hashtag.Text))
+			add("#" + strings.ToLower(hashtag.Text))
+		}
+
+		for _, symbol := range entities.Symbols {
+			add(strings.ToLower(symbol.Text))
+			add("$" + strings.ToLower(symbol.Text))
+		}
+
+		for _, mention := range entities.UserMentions {
+			add(strings.ToLower(mention.ScreenName))
+			add("@" + strings.ToLower(mention.ScreenName))
+		}
+
+		for _, u := range entities.URLs {
+			for _, term := range urlTerms(u.ExpandedURL) {
+				add(term)
+			}
+
+			for _, term := range urlTerms(u.DisplayURL) {
+				add(term)
+			}
+		}
+
+		for _, media := range entities.Media {
+			for _, term := range urlTerms(media.ExpandedURL) {
+				add(term)
+			}
+
+			for _, term := range urlTerms(media.DisplayURL) {
+				add(term)
+			}
+		}
+	}
+
+	return terms
+}
+
+// urlTerms splits a url into the terms it can be matched on. The host matches along with every parent domain so
+// "example.com" matches links to www.example.com, and every word in the url matches on its own.
+func urlTerms(rawURL string) []string {
+	rawURL = strings.ToLower(rawURL)
+	rawURL = strings.TrimPrefix(rawURL, "https://")
+	rawURL = strings.TrimPrefix(rawURL, "http://")
+	if rawURL == "" {
+		return nil
+	}
+
+	var terms []string
+
+	host := rawURL
+	if i := strings.IndexAny(host, "/?#"); i >= 0 {
+		host = host[:i]
+	}
+
+	labels := strings.Split(host, ".")
+	for i := 0; i < len(labels)-1; i++ {
+		terms = append(terms, strings.Join(labels[i:], "."))
+	}
+
+	terms = append(terms, strings.FieldsFunc(rawURL, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})...)
+
+	return terms
+}
+
+func isTrackPunctuation(r rune) bool {
+	return unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
+
+func isScreenNameRune(r rune) bool {
+	return r == '_' || (r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)))
+}
+
+func (b BoundingBox) contains(long, lat float64) bool {
+	return long >= b.SouthWestLong && long <= b.NorthEastLong && lat >= b.SouthWestLat && lat <= b.NorthEastLat
+}
+
+func (b BoundingBox) intersects(other BoundingBox) bool {
+	return b.SouthWestLong <= other.NorthEastLong && other.SouthWestLong <= b.NorthEastLong &&
+		b.SouthWestLat <= other.NorthEastLat && other.SouthWestLat <= b.NorthEastLat
+}
+
+// extent will return the smallest BoundingBox containing every point of the place's bounding box
+func (b PlaceBoundingBox) extent() (BoundingBox, bool) {
+	extent := BoundingBox{}
+	found := false
+
+	for _, ring := range b.Coordinates {
+		for _, point := range ring {
+			if len(point) != 2 {
+				continue
+			}
+
+			long, lat := point[0], point[1]
+			if !found {
+				extent = BoundingBox{SouthWestLong: long, SouthWestLat: lat, NorthEastLong: long, NorthEastLat: lat}
+				found = true
+				continue
+			}
+
+			if long < extent.SouthWestLong {
+				extent.SouthWestLong = long
+			}
+			if long > extent.NorthEastLong {
+				extent.NorthEastLong = long
+			}
+			if lat < extent.SouthWestLat {
+				extent.SouthWestLat = lat
+			}
+			if lat > extent.NorthEastLat {
+				extent.NorthEastLat = lat
+			}
+		}
+	}
+
+	return extent, found
+}
@@ -0,0 +1,125 @@
+package tweetgo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilterMatcherFollowsTrackSemantics(t *testing.T) {
+	m, err := NewFilterMatcher(StatusesFilterInput{Track: String("Twitter,hello world,#golang,example.com,$TWTR")})
+	if err != nil {
+		t.Fatalf("NewFilterMatcher failed: %s", err.Error())
+	}
+
+	tests := []struct {
+		raw      string
+		expected bool
+	}{
+		{`{"text":"I love TWITTER"}`, true},
+		{`{"text":"\"Twitter\" is down."}`, true},
+		{`{"text":"follow #twitter for news"}`, true},
+		{`{"text":"@twitter's office"}`, true},
+		{`{"text":"TwitterTracker is a thing"}`, false},
+		{`{"text":"#newtwitter"}`, false},
+		{`{"text":"world, hello!"}`, true},
+		{`{"text":"hello there"}`, false},
+		{`{"text":"golang is great"}`, false},
+		{`{"text":"#GoLang is great"}`, true},
+		{`{"text":"buy $twtr"}`, true},
+		{`{"text":"see https://t.co/abc"}`, false},
+		{`{"text":"mentioning someone","entities":{"user_mentions":[{"screen_name":"Twitter"}]}}`, true},
+		{`{"text":"see https://t.co/abc","entities":{"urls":[{"url":"https://t.co/abc","expanded_url":"https://www.example.com/page","display_url":"example.com/page"}]}}`, true},
+		{`{"text":"look https://t.co/pic","entities":{"media":[{"url":"https://t.co/pic","expanded_url":"https://example.com/photo/1","display_url":"pic.example.com/1"}]}}`, true},
+		{`{"text":"RT quoted","retweeted_status":{"text":"hello big world"}}`, true},
+		{`{"text":"what do you think?","quoted_status":{"text":"about #golang"}}`, true},
+	}
+
+	for _, test := range tests {
+		if matched := m.Match(decodeTestOutput(t, test.raw)); matched != test.expected {
+			t.Errorf("%s matched: %t != expected: %t", test.raw, matched, test.expected)
+		}
+	}
+}
+
+func TestFilterMatcherReturnsMatchedTrackPhrases(t *testing.T) {
+	m, _ := NewFilterMatcher(StatusesFilterInput{Track: String("go,Hello World,rust")})
+
+	matched := m.MatchedTrack(decodeTestOutput(t, `{"text":"Hello go world"}`))
+	expected := []string{"go", "hello world"}
+
+	if !reflect.DeepEqual(expected, matched) {
+		t.Fatalf("matched: %q != expected: %q", matched, expected)
+	}
+}
+
+func TestFilterMatcherFollowsUsers(t *testing.T) {
+	m, err := NewFilterMatcher(StatusesFilterInput{Follow: String("12, 13")})
+	if err != nil {
+		t.Fatalf("NewFilterMatcher failed: %s", err.Error())
+	}
+
+	tests := []struct {
+		raw      string
+		expected bool
+	}{
+		{`{"text":"by the user","user":{"id":12}}`, true},
+		{`{"text":"a reply","user":{"id":99},"in_reply_to_user_id":13}`, true},
+		{`{"text":"RT","user":{"id":99},"retweeted_status":{"text":"x","user":{"id":12}}}`, true},
+		{`{"text":"mention @jack","user":{"id":99},"entities":{"user_mentions":[{"id":12}]}}`, false},
+	}
+
+	for _, test := range tests {
+		if matched := m.Match(decodeTestOutput(t, test.raw)); matched != test.expected {
+			t.Errorf("%s matched: %t != expected: %t", test.raw, matched, test.expected)
+		}
+	}
+
+	if _, err := NewFilterMatcher(StatusesFilterInput{Follow: String("12,abc")}); err == nil {
+		t.Fatalf("expected an error for an invalid user id")
+	}
+}
+
+func TestFilterMatcherMatchesLocations(t *testing.T) {
+	m, err := NewFilterMatcher(StatusesFilterInput{Locations: String("-122.75,36.8,-121.75,37.8")})
+	if err != nil {
+		t.Fatalf("NewFilterMatcher failed: %s", err.Error())
+	}
+
+	tests := []struct {
+		raw      string
+		expected bool
+	}{
+		{`{"coordinates":{"type":"Point","coordinates":[-122.4,37.7]}}`, true},
+		{`{"coordinates":{"type":"Point","coordinates":[-74.0,40.7]}}`, false},
+		{`{"place":{"bounding_box":{"type":"Polygon","coordinates":[[[-123,37],[-123,38],[-122,38],[-122,37]]]}}}`, true},
+		{`{"place":{"bounding_box":{"type":"Polygon","coordinates":[[[-75,40],[-75,41],[-73,41],[-73,40]]]}}}`, false},
+		{`{"text":"no geo"}`, false},
+	}
+
+	for _, test := range tests {
+		if matched := m.Match(decodeTestOutput(t, test.raw)); matched != test.expected {
+			t.Errorf("%s matched: %t != expected: %t", test.raw, matched, test.expected)
+		}
+	}
+
+	if _, err := NewFilterMatcher(StatusesFilterInput{Locations: String("1,2,3")}); err == nil {
+		t.Fatalf("expected an error for an incomplete bounding box")
+	}
+}
+
+func TestFilterMatcherUsesExtendedTweets(t *testing.T) {
+	m, err := NewFilterMatcher(StatusesFilterInput{Track: String("ending,#late")})
+	if err != nil {
+		t.Fatalf("NewFilterMatcher failed: %s", err.Error())
+	}
+
+	output := decodeTestOutput(t, `{"text":"a long tweet…","truncated":true,"extended_tweet":{"full_text":"a long tweet with an ending"}}`)
+	if !m.Match(output) {
+		t.Fatalf("expected the full text of an extended tweet to match")
+	}
+
+	output = decodeTestOutput(t, `{"text":"a long tweet…","extended_tweet":{"full_text":"a long tweet #late","entities":{"hashtags":[{"text":"late"}]}}}`)
+	if matched := m.MatchedTrack(output); len(matched) != 1 || matched[0] != "#late" {
+		t.Fatalf("matched: %v != expected: [#late]", matched)
+	}
+}
@@ -0,0 +1,146 @@
+package tweetgo
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RoundTripFunc sends a signed request to twitter and returns its response. The response body has already been
+// decompressed.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps the sending of every request made by the client, for logging, metrics, tracing or quotas. It is
+// given the next step of the chain and returns the step to use in its place, which can change the request, inspect the
+// response and error, or short-circuit the chain by returning a response without calling next, for example to serve
+// a cached response. A response other than 200 OK returned by the chain is still turned into an HTTPError.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// Logger is where LoggingMiddleware writes, it is satisfied by *log.Logger
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// redacted replaces secrets in logs
+const redacted = "[REDACTED]"
+
+// redactedHeaders are the request headers which carry credentials
+var redactedHeaders = map[string]bool{
+	"Authorization": true,
+}
+
+// redactedParams are the oauth parameters which carry credentials, in a query string or a form body
+var redactedParams = map[string]bool{
+	"oauth_token":        true,
+	"oauth_token_secret": true,
+	"oauth_verifier":     true,
+	"oauth_signature":    true,
+	"x_auth_password":    true,
+}
+
+// LoggingMiddleware will log every request with its parameters and headers, and then the status and duration of its
+// response or the error. The Authorization header and oauth secrets in the parameters are redacted.
+func LoggingMiddleware(logger Logger) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			endpoint := req.Method + " " + redactURL(req.URL)
+			logger.Printf("tweetgo: %s body: %s headers: %s", endpoint, redactBody(req), redactHeader(req.Header))
+
+			start := time.Now()
+			res, err := next(req)
+			if err != nil {
+				logger.Printf("tweetgo: %s failed after %s: %s", endpoint, time.Since(start), err.Error())
+				return res, err
+			}
+
+			if res == nil {
+				logger.Printf("tweetgo: %s returned no response after %s", endpoint, time.Since(start))
+				return res, nil
+			}
+
+			logger.Printf("tweetgo: %s returned %s in %s", endpoint, res.Status, time.Since(start))
+
+			return res, nil
+		}
+	}
+}
+
+// chain will wrap roundTrip in every middleware, the first middleware sees the request first
+func (c Client) chain() RoundTripFunc {
+	next := c.roundTrip
+	for i := len(c.Middleware) - 1; i >= 0; i-- {
+		next = c.Middleware[i](next)
+	}
+
+	return next
+}
+
+func redactURL(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+
+	redactedURL := *u
+	redactedURL.RawQuery = redactValues(u.Query())
+
+	return redactedURL.String()
+}
+
+// redactBody will return the form body of req with its secrets redacted. The body is read from a copy so req can still
+// be sent.
+func redactBody(req *http.Request) string {
+	if req.GetBody == nil {
+		return ""
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	b, err := ioutil.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+
+	values, err := url.ParseQuery(string(b))
+	if err != nil {
+		return redacted
+	}
+
+	return redactValues(values)
+}
+
+func redactValues(values url.Values) string {
+	for key := range values {
+		if redactedParams[key] {
+			values[key] = []string{redacted}
+		}
+	}
+
+	return values.Encode()
+}
+
+func redactHeader(header http.Header) string {
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := make([]string, len(keys))
+	for i, key := range keys {
+		value := strings.Join(header[key], ", ")
+		if redactedHeaders[http.CanonicalHeaderKey(key)] {
+			value = redacted
+		}
+
+		fields[i] = key + ": " + value
+	}
+
+	return strings.Join(fields, "; ")
+}
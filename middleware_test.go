@@ -0,0 +1,120 @@
+package tweetgo
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMiddlewareRunsInOrder(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" request")
+				res, err := next(req)
+				calls = append(calls, name+" response")
+
+				return res, err
+			}
+		}
+	}
+
+	tc := NewClient("key", "secret")
+	tc.HTTPClient = newFakeClient(http.StatusOK, `{"statuses":[]}`)
+	tc.Middleware = []Middleware{record("first"), record("second")}
+
+	_, err := tc.SearchTweetsGet(SearchTweetsInput{Q: String("hello")})
+	if err != nil {
+		t.Fatalf("SearchTweetsGet failed: %s", err.Error())
+	}
+
+	expected := "first request, second request, second response, first response"
+	if strings.Join(calls, ", ") != expected {
+		t.Fatalf("calls: %s != expected: %s", strings.Join(calls, ", "), expected)
+	}
+}
+
+func TestMiddlewareCanShortCircuit(t *testing.T) {
+	cached := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("Authorization") == "" {
+				t.Fatalf("middleware should see the signed request")
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     "200 OK",
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(strings.NewReader(`{"statuses":[{"id":1,"text":"cached"}]}`)),
+			}, nil
+		}
+	}
+
+	tc := NewClient("key", "secret")
+	tc.HTTPClient = newFailingClient()
+	tc.Middleware = []Middleware{cached}
+
+	output, err := tc.SearchTweetsGet(SearchTweetsInput{Q: String("hello")})
+	if err != nil {
+		t.Fatalf("SearchTweetsGet failed: %s", err.Error())
+	}
+
+	if len(output.Statuses) != 1 || output.Statuses[0].Text != "cached" {
+		t.Fatalf("unexpected output: %+v", output)
+	}
+}
+
+func TestMiddlewareWithoutAResponseReturnsAnError(t *testing.T) {
+	empty := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			return nil, nil
+		}
+	}
+
+	tc := NewClient("key", "secret")
+	tc.HTTPClient = newFailingClient()
+	tc.Middleware = []Middleware{empty}
+
+	_, err := tc.SearchTweetsGet(SearchTweetsInput{Q: String("hello")})
+	if err != errNoResponse {
+		t.Fatalf("err: %v != expected: %v", err, errNoResponse)
+	}
+}
+
+func TestLoggingMiddlewareRedactsSecrets(t *testing.T) {
+	logs := bytes.Buffer{}
+
+	tc := NewClient("consumer-key", "consumer-secret")
+	tc.SetAccessKeys("access-token", "access-token-secret")
+	tc.HTTPClient = newFakeClient(http.StatusOK, "oauth_token=a&oauth_token_secret=b")
+	tc.Middleware = []Middleware{LoggingMiddleware(log.New(&logs, "", 0))}
+
+	_, err := tc.OAuthAccessTokenPost(OAuthAccessTokenInput{
+		OAuthToken:    String("request-token-value"),
+		OAuthVerifier: String("verifier-value"),
+	})
+	if err != nil {
+		t.Fatalf("OAuthAccessTokenPost failed: %s", err.Error())
+	}
+
+	for _, secret := range []string{"access-token", "request-token-value", "verifier-value", "oauth_signature"} {
+		if strings.Contains(logs.String(), secret) {
+			t.Fatalf("logs contain %s: %s", secret, logs.String())
+		}
+	}
+
+	expected := []string{
+		"tweetgo: POST https://api.twitter.com/oauth/access_token body: oauth_token=%5BREDACTED%5D&oauth_verifier=%5BREDACTED%5D",
+		"Authorization: [REDACTED]",
+		"tweetgo: POST https://api.twitter.com/oauth/access_token returned 200 OK in ",
+	}
+	for _, e := range expected {
+		if !strings.Contains(logs.String(), e) {
+			t.Fatalf("logs: %s != expected to contain: %s", logs.String(), e)
+		}
+	}
+}
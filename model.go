@@ -1,5 +1,7 @@
 package tweetgo
 
+import "encoding/json"
+
 // OAuthRequestTokenInput contains the possible inputs to the request token endpoint.
 type OAuthRequestTokenInput struct {
 	OAuthCallback   *string `schema:"oauth_callback"`
@@ -23,40 +25,40 @@ type OAuthAccessTokenInput struct {
 type OAuthAccessTokenOutput struct {
 	OAuthToken       string `schema:"oauth_token"`
 	OAuthTokenSecret string `schema:"oauth_token_secret"`
-	UserID           int64  `schema:"user_id"`
+	UserID           ID     `schema:"user_id"`
 	ScreenName       string `schema:"screen_name"`
 }
 
 // ListsListInput contains the possible inputs when listing a list
 type ListsListInput struct {
-	UserID     *int64  `schema:"user_id"`
+	UserID     *ID     `schema:"user_id"`
 	ScreenName *string `schema:"screen_name"`
 	Reverse    *bool   `schema:"reverse"`
 }
 
 // ListsListOutput contains the output of listing the lists
 type ListsListOutput struct {
-	ID              int64  `json:"id"`
-	IDStr           string `json:"id_str"`
-	Name            string `json:"name"`
-	URI             string `json:"uri"`
-	SubscriberCount int    `json:"subscriber_count"`
-	MemberCount     int    `json:"member_count"`
-	Mode            string `json:"mode"`
-	Description     string `json:"description"`
-	Slug            string `json:"slug"`
-	FullName        string `json:"full_name"`
-	CreatedAt       string `json:"created_at"`
-	Following       bool   `json:"following"`
-	User            user   `json:"user"`
+	ID              ID              `json:"id"`
+	Name            string          `json:"name"`
+	URI             string          `json:"uri"`
+	SubscriberCount int             `json:"subscriber_count"`
+	MemberCount     int             `json:"member_count"`
+	Mode            string          `json:"mode"`
+	Description     string          `json:"description"`
+	Slug            string          `json:"slug"`
+	FullName        string          `json:"full_name"`
+	CreatedAt       Time            `json:"created_at"`
+	Following       bool            `json:"following"`
+	User            User            `json:"user"`
+	Raw             json.RawMessage `json:"-"`
 }
 
 // ListsMembersInput contains the possible inputs when listing the members of a list
 type ListsMembersInput struct {
-	ListID          *int64  `schema:"list_id"`
+	ListID          *ID     `schema:"list_id"`
 	Slug            *string `schema:"slug"`
 	OwnerScreenName *string `schema:"owner_screen_name"`
-	OwnerID         *int64  `schema:"owner_id"`
+	OwnerID         *ID     `schema:"owner_id"`
 	Count           *int    `schema:"count"`
 	Cursor          *int    `schema:"cursor"`
 	IncludeEntities *bool   `schema:"include_entities"`
@@ -65,35 +67,37 @@ type ListsMembersInput struct {
 
 // ListsMembersOutput contians the output from listing the members of a list
 type ListsMembersOutput struct {
-	Users             []user `json:"users"`
-	NextCursor        int    `json:"next_cursor"`
-	NextCursorStr     string `json:"next_cursor_str"`
-	PreviousCursor    int    `json:"previous_cursor"`
-	PreviousCursorStr string `json:"previous_cursor_str"`
-	TotalCount        int    `json:"total_count"`
+	Users             []User          `json:"users"`
+	NextCursor        int             `json:"next_cursor"`
+	NextCursorStr     string          `json:"next_cursor_str"`
+	PreviousCursor    int             `json:"previous_cursor"`
+	PreviousCursorStr string          `json:"previous_cursor_str"`
+	TotalCount        int             `json:"total_count"`
+	Raw               json.RawMessage `json:"-"`
 }
 
 // ListsMembersShowInput contains the possible inputs for the lists/members/show endpoint
 type ListsMembersShowInput struct {
-	ListID          *int64  `schema:"list_id"`
+	ListID          *ID     `schema:"list_id"`
 	Slug            *string `schema:"slug"`
-	UserID          *int64  `schema:"user_id"`
+	UserID          *ID     `schema:"user_id"`
 	ScreenName      *string `schema:"screen_name"`
 	OwnerScreenName *string `schema:"owner_screen_name"`
-	OwnerID         *int64  `schema:"owner_id"`
+	OwnerID         *ID     `schema:"owner_id"`
 	IncludeEntities *bool   `schema:"include_entities"`
 	SkipStatus      *bool   `schema:"skip_status"`
 }
 
 // ListsMembersShowOutput contains the output for the lists/members/show endpoint
 type ListsMembersShowOutput struct {
-	user
+	User
+	Raw json.RawMessage `json:"-"`
 }
 
 // StatusesUpdateInput contains the possible inputs when updating a status
 type StatusesUpdateInput struct {
 	Status                    *string  `schema:"status"`
-	InReplyToStatusID         *int64   `schema:"in_reply_to_status_id"`
+	InReplyToStatusID         *ID      `schema:"in_reply_to_status_id"`
 	AutoPopulateReplyMetadata *bool    `schema:"auto_populate_reply_metadata"`
 	ExcludeReplyUserIDs       *string  `schema:"exclude_reply_user_ids"`
 	AttachmentURL             *string  `schema:"attachment_url"`
@@ -107,22 +111,13 @@ type StatusesUpdateInput struct {
 	EnableDMCommands          *bool    `schema:"enable_dmcommands"`
 	FailDMCommands            *bool    `schema:"fail_dmcommands"`
 	CardURI                   *string  `schema:"card_uri"`
+	TweetMode                 *string  `schema:"tweet_mode"`
 }
 
 // StatusesUpdateOutput contains the output from posting a status update
 type StatusesUpdateOutput struct {
-	CreatedAt            string `json:"created_at"`
-	ID                   int64  `json:"id"`
-	IDStr                string `json:"id_str"`
-	Text                 string `json:"text"`
-	Source               string `json:"source"`
-	Truncated            bool   `json:"truncated"`
-	InReplyToStatusID    int64  `json:"in_reply_to_status_id"`
-	InReplyToStatusIDStr string `json:"in_reply_to_status_id_str"`
-	InReplyToUserID      int64  `json:"in_reply_to_user_id"`
-	InReplyToUserIDStr   string `json:"in_reply_to_user_id_str"`
-	InReplyToScreenName  string `json:"in_reply_to_screen_name"`
-	User                 user   `json:"user"`
+	Tweet
+	Raw json.RawMessage `json:"-"`
 }
 
 // StatusesFilterInput contains the input options for getting filtered statuses
@@ -136,75 +131,212 @@ type StatusesFilterInput struct {
 
 // StatusesFilterOutput contains the output for a single response from the filtered statuses endpoint
 type StatusesFilterOutput struct {
-	tweet
-	QuotedStatus    tweet `json:"quoted_status"`
-	RetweetedStatus tweet `json:"retweeted_status"`
-	ExtendedTweet   tweet `json:"extended_tweet"`
+	Tweet
+	QuotedStatus    Tweet `json:"quoted_status"`
+	RetweetedStatus Tweet `json:"retweeted_status"`
+	// Backfilled is true when the tweet was missed while the stream was disconnected and recovered with a search
+	Backfilled bool `json:"-"`
+	// Raw is the JSON the tweet was decoded from, including any attributes that don't have a field
+	Raw json.RawMessage `json:"-"`
+}
+
+// StatusesSampleInput contains the input options for getting the sample of all public statuses
+type StatusesSampleInput struct {
+	Delimited     *string `schema:"delimited"`
+	StallWarnings *string `schema:"stall_warnings"`
 }
 
 // StatusesUserTimelineInput contains the input options for getting the users timeline statuses
 type StatusesUserTimelineInput struct {
-	UserID         *int64  `schema:"user_id"`
+	UserID         *ID     `schema:"user_id"`
 	ScreenName     *string `schema:"screen_name"`
-	SinceID        *int64  `schema:"since_id"`
+	SinceID        *ID     `schema:"since_id"`
 	Count          *int    `schema:"count"`
-	MaxID          *int64  `schema:"max_id"`
+	MaxID          *ID     `schema:"max_id"`
 	TrimUser       *bool   `schema:"trim_user"`
 	ExcludeReplies *bool   `schema:"exclude_replies"`
 	IncludeRts     *bool   `schema:"include_rts"`
+	TweetMode      *string `schema:"tweet_mode"`
 }
 
 // StatusesUserTimelineOutput contains the output for a response from the users timeline endpoint
 type StatusesUserTimelineOutput struct {
-	tweet
+	Tweet
+	QuotedStatus    Tweet `json:"quoted_status"`
+	RetweetedStatus Tweet `json:"retweeted_status"`
+	// Raw is the JSON the tweet was decoded from, including any attributes that don't have a field
+	Raw json.RawMessage `json:"-"`
+}
+
+// SearchTweetsInput contains the input options for searching recent tweets
+type SearchTweetsInput struct {
+	Q               *string `schema:"q"`
+	Geocode         *string `schema:"geocode"`
+	Lang            *string `schema:"lang"`
+	Locale          *string `schema:"locale"`
+	ResultType      *string `schema:"result_type"`
+	Count           *int    `schema:"count"`
+	Until           *string `schema:"until"`
+	SinceID         *ID     `schema:"since_id"`
+	MaxID           *ID     `schema:"max_id"`
+	IncludeEntities *bool   `schema:"include_entities"`
+	TweetMode       *string `schema:"tweet_mode"`
+}
+
+// SearchTweetsOutput contains the output from searching recent tweets. Statuses have the same shape as the tweets
+// delivered by the filter stream, but only the SearchTweetsOutput keeps Raw.
+type SearchTweetsOutput struct {
+	Statuses       []StatusesFilterOutput `json:"statuses"`
+	SearchMetadata SearchMetadata         `json:"search_metadata"`
+	Raw            json.RawMessage        `json:"-"`
 }
 
 // ---------------------------------------------------------------------------------------------------------------------
-// STRUCTS FOR COMPOSITION
+// STREAM MESSAGES
+// https://developer.twitter.com/en/docs/tweets/filter-realtime/guides/streaming-message-types
 // ---------------------------------------------------------------------------------------------------------------------
 
-// https://developer.twitter.com/en/docs/tweets/data-dictionary/overview/tweet-object
-type tweet struct {
-	CreatedAt            string           `json:"created_at"`
-	ID                   int64            `json:"id"`
-	IDStr                string           `json:"id_str"`
-	Text                 string           `json:"text"`
-	Source               string           `json:"source"`
-	Truncated            bool             `json:"truncated"`
-	InReplyToStatusID    int64            `json:"in_reply_to_status_id"`
-	InReplyToStatusIDStr string           `json:"in_reply_to_status_id_str"`
-	InReplyToUserID      int64            `json:"in_reply_to_user_id"`
-	InReplyToUserIDStr   string           `json:"in_reply_to_user_id_str"`
-	InReplyToScreenName  string           `json:"in_reply_to_screen_name"`
-	User                 user             `json:"user"`
-	Coordinates          coordinates      `json:"coordinates"`
-	Place                place            `json:"place"`
-	QuotedStatusID       int64            `json:"quoted_status_id"`
-	QuotedStatusIDStr    string           `json:"quoted_status_id_str"`
-	IsQuoteStatus        bool             `json:"is_quote_status"`
-	QuoteCount           int              `json:"quote_count"`
-	ReplyCount           int              `json:"reply_count"`
-	RetweetCount         int              `json:"retweet_count"`
-	FavoriteCount        int              `json:"favorite_count"`
-	Entities             entities         `json:"entities"`
-	ExtendedEntities     extendedEntities `json:"extended_entities"`
-	Favorited            bool             `json:"favorited"`
-	Retweeted            bool             `json:"retweeted"`
-	PossiblySensitive    bool             `json:"possibly_sensitive"`
-	FilterLevel          string           `json:"filter_level"`
-	Lang                 string           `json:"lang"`
-	MatchingRules        []rule           `json:"matching_rules"`
-	// I did not include any "Additional Tweet attributes"
+// StreamDelete is sent when a tweet has been deleted. Any stored copy of the tweet must be deleted as well.
+type StreamDelete struct {
+	Status      DeletedStatus `json:"status"`
+	TimestampMS string        `json:"timestamp_ms"`
 }
 
+// StreamScrubGeo is sent when a user has removed the geo information from their tweets. Geo information must be
+// removed from all of the users tweets up to and including UpToStatusID.
+type StreamScrubGeo struct {
+	UserID       ID     `json:"user_id"`
+	UpToStatusID ID     `json:"up_to_status_id"`
+	TimestampMS  string `json:"timestamp_ms"`
+}
+
+// StreamLimit is sent when the stream matched more tweets than it is allowed to deliver. Track is the total number of
+// undelivered tweets since the connection was opened.
+type StreamLimit struct {
+	Track       int    `json:"track"`
+	TimestampMS string `json:"timestamp_ms"`
+}
+
+// StreamStatusWithheld is sent when a tweet has been withheld in certain countries
+type StreamStatusWithheld struct {
+	ID                  ID       `json:"id"`
+	UserID              ID       `json:"user_id"`
+	WithheldInCountries []string `json:"withheld_in_countries"`
+	TimestampMS         string   `json:"timestamp_ms"`
+}
+
+// StreamUserWithheld is sent when a user has been withheld in certain countries
+type StreamUserWithheld struct {
+	ID                  ID       `json:"id"`
+	WithheldInCountries []string `json:"withheld_in_countries"`
+	TimestampMS         string   `json:"timestamp_ms"`
+}
+
+// StreamDisconnect is sent right before twitter closes the connection
+type StreamDisconnect struct {
+	Code       int    `json:"code"`
+	StreamName string `json:"stream_name"`
+	Reason     string `json:"reason"`
+}
+
+// StreamWarning is sent when the stream is in danger of being disconnected, for example FALLING_BEHIND when the
+// client is reading too slowly. Warnings are only sent when the stream was opened with stall_warnings=true.
+type StreamWarning struct {
+	Code        string `json:"code"`
+	Message     string `json:"message"`
+	PercentFull int    `json:"percent_full"`
+	TimestampMS string `json:"timestamp_ms"`
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+// DATA DICTIONARY
+// These types are shared by the endpoint outputs and can be composed into new outputs.
+// https://developer.twitter.com/en/docs/tweets/data-dictionary/overview/intro-to-tweet-json
+// ---------------------------------------------------------------------------------------------------------------------
+
+// Tweet is the basic building block of all things twitter
+// https://developer.twitter.com/en/docs/tweets/data-dictionary/overview/tweet-object
+type Tweet struct {
+	CreatedAt           Time               `json:"created_at"`
+	ID                  ID                 `json:"id"`
+	Text                string             `json:"text"`
+	FullText            string             `json:"full_text"`
+	DisplayTextRange    []int              `json:"display_text_range"`
+	Source              string             `json:"source"`
+	Truncated           bool               `json:"truncated"`
+	InReplyToStatusID   ID                 `json:"in_reply_to_status_id"`
+	InReplyToUserID     ID                 `json:"in_reply_to_user_id"`
+	InReplyToScreenName string             `json:"in_reply_to_screen_name"`
+	User                User               `json:"user"`
+	Coordinates         Coordinates        `json:"coordinates"`
+	Place               Place              `json:"place"`
+	QuotedStatusID      ID                 `json:"quoted_status_id"`
+	IsQuoteStatus       bool               `json:"is_quote_status"`
+	QuoteCount          int                `json:"quote_count"`
+	ReplyCount          int                `json:"reply_count"`
+	RetweetCount        int                `json:"retweet_count"`
+	FavoriteCount       int                `json:"favorite_count"`
+	Entities            Entities           `json:"entities"`
+	ExtendedEntities    ExtendedEntities   `json:"extended_entities"`
+	ExtendedTweet       ExtendedTweet      `json:"extended_tweet"`
+	Favorited           bool               `json:"favorited"`
+	Retweeted           bool               `json:"retweeted"`
+	PossiblySensitive   bool               `json:"possibly_sensitive"`
+	FilterLevel         string             `json:"filter_level"`
+	Lang                string             `json:"lang"`
+	MatchingRules       []MatchingRule     `json:"matching_rules"`
+	CurrentUserRetweet  CurrentUserRetweet `json:"current_user_retweet"`
+	WithheldCopyright   bool               `json:"withheld_copyright"`
+	WithheldInCountries []string           `json:"withheld_in_countries"`
+	WithheldScope       string             `json:"withheld_scope"`
+	Scopes              Scopes             `json:"scopes"`
+	EditHistory         EditHistory        `json:"edit_history"`
+	EditControls        EditControls       `json:"edit_controls"`
+}
+
+// CurrentUserRetweet identifies the authenticated user's retweet of a tweet, only included when requested with
+// include_my_retweet
+type CurrentUserRetweet struct {
+	ID ID `json:"id"`
+}
+
+// Scopes contains the context of a promoted tweet
+type Scopes struct {
+	Followers bool `json:"followers"`
+}
+
+// EditHistory contains the ids of every version of a tweet, starting with the original
+// https://developer.twitter.com/en/docs/twitter-api/edit-tweets
+type EditHistory struct {
+	InitialTweetID ID   `json:"initial_tweet_id"`
+	EditTweetIDs   []ID `json:"edit_tweet_ids"`
+}
+
+// EditControls describes whether and for how long a tweet can still be edited
+type EditControls struct {
+	EditsRemaining  int   `json:"edits_remaining"`
+	IsEditEligible  bool  `json:"is_edit_eligible"`
+	EditableUntilMS int64 `json:"editable_until_ms"`
+}
+
+// ExtendedTweet contains the untruncated text and entities of a tweet longer than 140 characters. It is only sent by
+// the streaming API, REST endpoints return the same fields on the tweet when tweet_mode is extended.
+// https://developer.twitter.com/en/docs/tweets/tweet-updates
+type ExtendedTweet struct {
+	FullText         string           `json:"full_text"`
+	DisplayTextRange []int            `json:"display_text_range"`
+	Entities         Entities         `json:"entities"`
+	ExtendedEntities ExtendedEntities `json:"extended_entities"`
+}
+
+// User is the account that created a tweet, or that is mentioned or followed
 // https://developer.twitter.com/en/docs/tweets/data-dictionary/overview/user-object
-type user struct {
-	ID                   int64    `json:"id"`
-	IDStr                string   `json:"id_str"`
+type User struct {
+	ID                   ID       `json:"id"`
 	Name                 string   `json:"name"`
 	ScreenName           string   `json:"screen_name"`
 	Location             string   `json:"location"`
-	Derived              derived  `json:"derived"`
+	Derived              Derived  `json:"derived"`
 	URL                  string   `json:"url"`
 	Description          string   `json:"description"`
 	Protected            bool     `json:"protected"`
@@ -214,7 +346,7 @@ type user struct {
 	ListedCount          int      `json:"listed_count"`
 	FavouritesCount      int      `json:"favourites_count"`
 	StatusesCount        int      `json:"statuses_count"`
-	CreatedAt            string   `json:"created_at"`
+	CreatedAt            Time     `json:"created_at"`
 	ProfileBannerURL     string   `json:"profile_banner_url"`
 	ProfileImageURLHTTPS string   `json:"profile_image_url_https"`
 	DefaultProfile       bool     `json:"default_profile"`
@@ -223,143 +355,192 @@ type user struct {
 	WithheldScope        string   `json:"withheld_scope"`
 }
 
+// Derived contains the locations twitter derived from a user's profile, only available to enterprise products
 // https://developer.twitter.com/en/docs/tweets/enrichments/overview/profile-geo
-type derived struct {
-	Locations []location `json:"locations"`
+type Derived struct {
+	Locations []Location `json:"locations"`
 }
 
-type location struct {
+// Location is a single location derived from a user's profile
+type Location struct {
 	Country     string      `json:"country"`
 	CountryCode string      `json:"country_code"`
 	Locality    string      `json:"locality"`
 	Region      string      `json:"region"`
 	SubRegion   string      `json:"sub_region"`
 	FullName    string      `json:"full_name"`
-	Geo         coordinates `json:"geo"`
+	Geo         Coordinates `json:"geo"`
 }
 
-type coordinates struct {
+// Coordinates is a GeoJSON point
+// https://developer.twitter.com/en/docs/tweets/data-dictionary/overview/geo-objects
+type Coordinates struct {
 	// Coordinates will be listed as [long, lat]
 	Coordinates []float64 `json:"coordinates"`
 	Type        string    `json:"type"`
 }
 
-type place struct {
-	ID          string      `json:"id"`
-	URL         string      `json:"url"`
-	PlaceType   string      `json:"place_type"`
-	Name        string      `json:"name"`
-	FullName    string      `json:"full_name"`
-	CountryCode string      `json:"country_code"`
-	BoundingBox boundingBox `json:"bounding_box"`
+// Place is a named location a tweet is associated with, though it wasn't necessarily sent from there
+// https://developer.twitter.com/en/docs/tweets/data-dictionary/overview/geo-objects
+type Place struct {
+	ID          string            `json:"id"`
+	URL         string            `json:"url"`
+	PlaceType   string            `json:"place_type"`
+	Name        string            `json:"name"`
+	FullName    string            `json:"full_name"`
+	CountryCode string            `json:"country_code"`
+	Country     string            `json:"country"`
+	BoundingBox PlaceBoundingBox  `json:"bounding_box"`
+	Attributes  map[string]string `json:"attributes"`
 }
 
-type boundingBox struct {
+// PlaceBoundingBox is the GeoJSON polygon which encloses a Place
+type PlaceBoundingBox struct {
+	// Coordinates is a list of polygons, each a list of [long, lat] points
 	Coordinates [][][]float64 `json:"coordinates"`
 	Type        string        `json:"type"`
 }
 
-type entities struct {
-	Hashtags     []hashtag      `json:"hashtags"`
-	Media        []media        `json:"media"`
-	URLs         []entityURL    `json:"urls"`
-	UserMentions []userMentions `json:"user_mentions"`
-	Symbols      []symbol       `json:"symbols"`
-	Polls        []poll         `json:"polls"`
+// Entities contains everything twitter parsed out of the text of a tweet
+// https://developer.twitter.com/en/docs/tweets/data-dictionary/overview/entities-object
+type Entities struct {
+	Hashtags     []Hashtag     `json:"hashtags"`
+	Media        []Media       `json:"media"`
+	URLs         []EntityURL   `json:"urls"`
+	UserMentions []UserMention `json:"user_mentions"`
+	Symbols      []Symbol      `json:"symbols"`
+	Polls        []Poll        `json:"polls"`
 }
 
+// ExtendedEntities contains every photo, video or animated GIF attached to a tweet. Entities only contains the first.
 // https://developer.twitter.com/en/docs/tweets/data-dictionary/overview/extended-entities-object
-type extendedEntities struct {
-	Media []media `json:"media"`
+type ExtendedEntities struct {
+	Media []Media `json:"media"`
 }
 
-type hashtag struct {
+// Hashtag is a hashtag found in the text of a tweet, Text doesn't include the #
+type Hashtag struct {
 	Indices []int  `json:"indices"`
 	Text    string `json:"text"`
 }
 
-type media struct {
+// Media is a photo, video or animated GIF attached to a tweet
+type Media struct {
 	DisplayURL          string              `json:"display_url"`
 	ExpandedURL         string              `json:"expanded_url"`
-	ID                  int64               `json:"id"`
-	IDStr               string              `json:"id_str"`
+	ID                  ID                  `json:"id"`
 	Indices             []int               `json:"indices"`
 	MediaURL            string              `json:"media_url"`
 	MediaURLHTTPS       string              `json:"media_url_https"`
-	Sizes               sizes               `json:"sizes"`
-	SourceStatusID      int64               `json:"source_status_id"`
-	SourceStatusIDStr   string              `json:"source_status_id_str"`
-	Type                string              `json:"photo"`
+	Sizes               MediaSizes          `json:"sizes"`
+	SourceStatusID      ID                  `json:"source_status_id"`
+	Type                string              `json:"type"`
 	URL                 string              `json:"url"`
-	VideoInfo           videoInfo           `json:"video_info"`
-	AdditionalMediaInfo additionalMediaInfo `json:"additional_media_info"`
+	VideoInfo           VideoInfo           `json:"video_info"`
+	AdditionalMediaInfo AdditionalMediaInfo `json:"additional_media_info"`
 }
 
-type sizes struct {
-	Thumb  size `json:"thumb"`
-	Large  size `json:"large"`
-	Medium size `json:"medium"`
-	Small  size `json:"small"`
+// MediaSizes contains the dimensions of every size a Media is available in
+type MediaSizes struct {
+	Thumb  MediaSize `json:"thumb"`
+	Large  MediaSize `json:"large"`
+	Medium MediaSize `json:"medium"`
+	Small  MediaSize `json:"small"`
 }
 
-type size struct {
+// MediaSize is the dimensions of a single size of a Media. Resize is either "fit" or "crop".
+type MediaSize struct {
 	W      int    `json:"w"`
 	H      int    `json:"h"`
 	Resize string `json:"resize"`
 }
 
-type entityURL struct {
-	DisplayURL  string `json:"display_url"`
-	ExpandedURL string `json:"expanded_url"`
-	Indices     []int  `json:"indices"`
+// EntityURL is a link found in the text of a tweet. URL is the t.co link which appears in the text.
+type EntityURL struct {
+	DisplayURL  string     `json:"display_url"`
+	ExpandedURL string     `json:"expanded_url"`
+	Indices     []int      `json:"indices"`
+	URL         string     `json:"url"`
+	Unwound     URLUnwound `json:"unwound"`
+}
+
+// URLUnwound contains the final destination of a link, only available to enterprise products
+// https://developer.twitter.com/en/docs/tweets/enrichments/overview/expanded-and-enhanced-urls
+type URLUnwound struct {
 	URL         string `json:"url"`
+	Status      int    `json:"status"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
 }
 
-type userMentions struct {
-	ID         int64  `json:"id"`
-	IDStr      string `json:"id_str"`
+// UserMention is a user mentioned in the text of a tweet
+type UserMention struct {
+	ID         ID     `json:"id"`
 	Indices    []int  `json:"indices"`
 	Name       string `json:"name"`
 	ScreenName string `json:"screen_name"`
 }
 
-type symbol struct {
-	Indices []int  `json:"indicies"`
+// Symbol is a cashtag found in the text of a tweet, Text doesn't include the $
+type Symbol struct {
+	Indices []int  `json:"indices"`
 	Text    string `json:"text"`
 }
 
-type poll struct {
-	Options         []option `json:"options"`
-	EndDatetime     string   `json:"end_datetime"`
-	DurationMinutes int      `json:"duration_minutes"`
+// Poll is a poll attached to a tweet, only available to enterprise products
+type Poll struct {
+	Options         []PollOption `json:"options"`
+	EndDatetime     Time         `json:"end_datetime"`
+	DurationMinutes int          `json:"duration_minutes"`
 }
 
-type option struct {
+// PollOption is a single choice in a Poll
+type PollOption struct {
 	Position int    `json:"position"`
 	Text     string `json:"text"`
 }
 
-type videoInfo struct {
+// VideoInfo describes the encodings a video or animated GIF is available in
+type VideoInfo struct {
 	AspectRatio    []int          `json:"aspect_ratio"`
 	DurationMillis int            `json:"duration_millis"`
-	Variants       []videoVariant `json:"variants"`
+	Variants       []VideoVariant `json:"variants"`
 }
 
-type videoVariant struct {
+// VideoVariant is a single encoding of a video
+type VideoVariant struct {
 	Bitrate     int    `json:"bitrate"`
 	ContentType string `json:"content_type"`
 	URL         string `json:"url"`
 }
 
-type additionalMediaInfo struct {
+// AdditionalMediaInfo is extra information about media uploaded by advertisers
+type AdditionalMediaInfo struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	Embeddable  bool   `json:"embeddable"`
 	Monetizable bool   `json:"monetizable"`
 }
 
-type rule struct {
-	Tag   string `json:"tag"`
-	ID    int64  `json:"id"`
-	IDStr string `json:"id_str"`
+// MatchingRule is a filtering rule which matched a tweet, only available to enterprise products
+type MatchingRule struct {
+	Tag string `json:"tag"`
+	ID  ID     `json:"id"`
+}
+
+// SearchMetadata describes the search which produced a SearchTweetsOutput
+type SearchMetadata struct {
+	CompletedIn float64 `json:"completed_in"`
+	MaxID       ID      `json:"max_id"`
+	NextResults string  `json:"next_results"`
+	Query       string  `json:"query"`
+	RefreshURL  string  `json:"refresh_url"`
+	Count       int     `json:"count"`
+	SinceID     ID      `json:"since_id"`
+}
+
+// DeletedStatus identifies the tweet removed by a StreamDelete
+type DeletedStatus struct {
+	ID     ID `json:"id"`
+	UserID ID `json:"user_id"`
 }
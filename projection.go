@@ -0,0 +1,257 @@
+package tweetgo
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// Projection decodes only some of the fields of a tweet, which is much faster on busy streams when most of a tweet
+// isn't needed. Fields are selected by their JSON path, such as "id", "text", "user.id" or "entities.hashtags". The
+// rest of the tweet isn't decoded but is still available in Raw.
+type Projection struct {
+	root *projectionNode
+}
+
+// projectionNode is a selected field. A node without children is decoded in full, otherwise only its children are.
+type projectionNode struct {
+	index    []int
+	children map[string]*projectionNode
+}
+
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+// unmarshalFunc decodes a JSON value, like json.Unmarshal
+type unmarshalFunc func(data []byte, v interface{}) error
+
+// NewProjection will create a Projection which decodes fields into a StatusesFilterOutput. The id is always decoded
+// because streams use it to remove duplicates. An error is returned for a path that doesn't exist, or which selects
+// fields inside a list.
+func NewProjection(fields ...string) (*Projection, error) {
+	if len(fields) == 0 {
+		return nil, errors.New("projection requires at least one field")
+	}
+
+	root := &projectionNode{children: map[string]*projectionNode{}}
+	for _, field := range append([]string{"id"}, fields...) {
+		node := root
+		t := reflect.TypeOf(StatusesFilterOutput{})
+
+		for _, name := range strings.Split(field, ".") {
+			if t.Kind() != reflect.Struct {
+				return nil, errors.New("projection field " + field + " selects inside a value which isn't an object")
+			}
+
+			if node.children == nil {
+				// the parent was already selected in full
+				break
+			}
+
+			sf, ok := jsonField(t, name)
+			if !ok {
+				return nil, errors.New("projection field " + field + " doesn't exist")
+			}
+
+			child, ok := node.children[name]
+			if !ok {
+				child = &projectionNode{index: sf.Index, children: map[string]*projectionNode{}}
+				node.children[name] = child
+			}
+
+			node = child
+			t = sf.Type
+		}
+
+		// selecting a field in full replaces any of its children which were selected before
+		node.children = nil
+	}
+
+	return &Projection{root: root}, nil
+}
+
+// Decode will decode the selected fields of msg into output, keeping msg in output.Raw
+func (p *Projection) Decode(msg []byte, output *StatusesFilterOutput) error {
+	return p.decode(msg, output, json.Unmarshal)
+}
+
+func (p *Projection) decode(msg []byte, output *StatusesFilterOutput, unmarshal unmarshalFunc) error {
+	return decodeProjection(msg, reflect.ValueOf(output).Elem(), p.root, unmarshal)
+}
+
+func decodeProjection(data []byte, v reflect.Value, node *projectionNode, unmarshal unmarshalFunc) error {
+	if node.children == nil {
+		return unmarshal(data, v.Addr().Interface())
+	}
+
+	if string(data) == "null" {
+		return nil
+	}
+
+	err := eachJSONField(data, func(key []byte, value []byte) error {
+		child, ok := node.children[string(key)]
+		if !ok {
+			return nil
+		}
+
+		return decodeProjection(value, v.FieldByIndex(child.index), child, unmarshal)
+	})
+	if err != nil {
+		return err
+	}
+
+	// keep the original JSON for the fields which weren't decoded
+	if raw := v.FieldByName("Raw"); raw.IsValid() && raw.Type() == rawMessageType {
+		raw.Set(reflect.ValueOf(copyRaw(data)))
+	}
+
+	return nil
+}
+
+// jsonField will find the field of t, or of a struct embedded in t, which is decoded from the JSON key name
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		tag := strings.Split(sf.Tag.Get("json"), ",")[0]
+		if tag == name && tag != "-" {
+			return sf, true
+		}
+
+		if sf.Anonymous && tag == "" && sf.Type.Kind() == reflect.Struct {
+			embedded, ok := jsonField(sf.Type, name)
+			if ok {
+				embedded.Index = append([]int{i}, embedded.Index...)
+				return embedded, true
+			}
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+var errInvalidJSON = errors.New("invalid JSON object")
+
+// eachJSONField will call fn with the key and raw value of every field in a JSON object, without decoding the values.
+// It only checks enough of the syntax to find where each value ends, the values passed to fn are validated when they
+// are decoded.
+func eachJSONField(data []byte, fn func(key []byte, value []byte) error) error {
+	i := skipJSONSpace(data, 0)
+	if i >= len(data) || data[i] != '{' {
+		return errInvalidJSON
+	}
+
+	i = skipJSONSpace(data, i+1)
+	if i < len(data) && data[i] == '}' {
+		return nil
+	}
+
+	for i < len(data) {
+		if data[i] != '"' {
+			return errInvalidJSON
+		}
+
+		end := skipJSONString(data, i)
+		if end < 0 {
+			return errInvalidJSON
+		}
+		key := data[i+1 : end-1]
+
+		i = skipJSONSpace(data, end)
+		if i >= len(data) || data[i] != ':' {
+			return errInvalidJSON
+		}
+
+		start := skipJSONSpace(data, i+1)
+		end = skipJSONValue(data, start)
+		if end < 0 {
+			return errInvalidJSON
+		}
+
+		err := fn(key, data[start:end])
+		if err != nil {
+			return err
+		}
+
+		i = skipJSONSpace(data, end)
+		if i < len(data) && data[i] == '}' {
+			return nil
+		}
+
+		if i >= len(data) || data[i] != ',' {
+			return errInvalidJSON
+		}
+
+		i = skipJSONSpace(data, i+1)
+	}
+
+	return errInvalidJSON
+}
+
+func skipJSONSpace(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\r' || data[i] == '\n') {
+		i++
+	}
+
+	return i
+}
+
+// skipJSONString will return the index after the string starting at i, or -1 if it isn't terminated
+func skipJSONString(data []byte, i int) int {
+	for i++; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+
+	return -1
+}
+
+// skipJSONValue will return the index after the value starting at i, or -1 if it isn't complete
+func skipJSONValue(data []byte, i int) int {
+	if i >= len(data) {
+		return -1
+	}
+
+	switch data[i] {
+	case '"':
+		return skipJSONString(data, i)
+	case '{', '[':
+		depth := 0
+		for i < len(data) {
+			switch data[i] {
+			case '"':
+				i = skipJSONString(data, i)
+				if i < 0 {
+					return -1
+				}
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return i + 1
+				}
+			}
+			i++
+		}
+
+		return -1
+	default:
+		start := i
+		for i < len(data) && data[i] != ',' && data[i] != '}' && data[i] != ']' &&
+			data[i] != ' ' && data[i] != '\t' && data[i] != '\r' && data[i] != '\n' {
+			i++
+		}
+
+		if i == start {
+			return -1
+		}
+
+		return i
+	}
+}
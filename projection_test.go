@@ -0,0 +1,104 @@
+package tweetgo
+
+import (
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestProjectionOnlyDecodesSelectedFields(t *testing.T) {
+	p, err := NewProjection("id", "text", "user.id", "entities.hashtags", "quoted_status")
+	if err != nil {
+		t.Fatalf("NewProjection failed: %s", err.Error())
+	}
+
+	raw := `{"created_at":"Wed Oct 10 20:19:24 +0000 2018","id":1,"text":"hi {\"quoted\"} #go","lang":"en",` +
+		`"user":{"id":2,"screen_name":"gopher","entities":{"url":{"urls":[]}}},` +
+		`"entities":{"hashtags":[{"text":"go","indices":[17,20]}],"urls":[{"url":"https://t.co/abc"}]},` +
+		`"quoted_status":{"id":3,"text":"quoted"},"retweeted_status":null}`
+
+	output := StatusesFilterOutput{}
+	err = p.Decode([]byte(raw), &output)
+	if err != nil {
+		t.Fatalf("Decode failed: %s", err.Error())
+	}
+
+	if output.ID != 1 || output.Text != `hi {"quoted"} #go` || output.User.ID != 2 || output.QuotedStatus.Text != "quoted" {
+		t.Fatalf("unexpected output: %+v", output)
+	}
+
+	if !reflect.DeepEqual(output.Entities.Hashtags, []Hashtag{{Text: "go", Indices: []int{17, 20}}}) {
+		t.Fatalf("hashtags: %+v != expected: [go]", output.Entities.Hashtags)
+	}
+
+	if output.Lang != "" || output.User.ScreenName != "" || len(output.Entities.URLs) != 0 || !output.CreatedAt.IsZero() {
+		t.Fatalf("fields which weren't selected were decoded: %+v", output)
+	}
+
+	if string(output.Raw) != raw {
+		t.Fatalf("raw: %s != expected: %s", output.Raw, raw)
+	}
+}
+
+func TestProjectionRejectsUnknownFields(t *testing.T) {
+	for _, field := range []string{"missing", "user.missing", "entities.hashtags.text", "id.value", "-"} {
+		if _, err := NewProjection(field); err == nil {
+			t.Errorf("expected an error for projection field %s", field)
+		}
+	}
+}
+
+func TestProjectionRejectsInvalidJSON(t *testing.T) {
+	p, err := NewProjection("id", "user.id")
+	if err != nil {
+		t.Fatalf("NewProjection failed: %s", err.Error())
+	}
+
+	for _, raw := range []string{`{"id":1`, `{"id":1,"user":{"id":2}`, `[1]`, `{"id" 1}`, `{"id":"1}`} {
+		output := StatusesFilterOutput{}
+		if err := p.Decode([]byte(raw), &output); err == nil {
+			t.Errorf("expected an error decoding %s", raw)
+		}
+	}
+}
+
+func TestStreamsDecodeTweetsWithTheProjection(t *testing.T) {
+	body := `{"created_at":"Wed Oct 10 20:19:24 +0000 2018","id":1,"text":"hello","lang":"en"}` + "\r\n" +
+		`{"created_at":"Wed Oct 10 20:19:25 +0000 2018","id":2,"text":"again","lang":"en"}` + "\r\n" +
+		`{"created_at":"Wed Oct 10 20:19:26 +0000 2018","id":3,"text":"bye","lang":"en"}` + "\r\n"
+	fs := NewFilterStream(newTestResponse(body), false)
+	fs.Backfill = true
+	fs.Projection, _ = NewProjection("text")
+
+	var outputs []StatusesFilterOutput
+	err := fs.Run(func(output StatusesFilterOutput) {
+		outputs = append(outputs, output)
+	})
+	if err != io.EOF {
+		t.Fatalf("expected io.EOF at the end of the body, got: %v", err)
+	}
+
+	// id is always decoded so duplicates can still be removed
+	if len(outputs) != 3 || outputs[0].Text != "hello" || outputs[2].ID != 3 || outputs[0].Lang != "" {
+		t.Fatalf("unexpected outputs: %+v", outputs)
+	}
+}
+
+func BenchmarkDispatchTweetWithProjection(b *testing.B) {
+	msg := benchmarkStreamTweet(b)
+	p, err := NewProjection("id", "text", "user.id", "entities.hashtags")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	d := StreamDemux{Tweet: func(StatusesFilterOutput) {}, Projection: p}
+	b.SetBytes(int64(len(msg)))
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		err := d.Dispatch(msg)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package tweetgo
+
+import "encoding/json"
+
+// The outputs below keep a copy of the JSON they were decoded from in Raw, so attributes twitter adds before they have
+// a field can still be read, and the original payload can be re-emitted exactly. Only the outputs returned by an
+// endpoint keep Raw, the tweets and users inside them don't, so a payload isn't stored once for every level it nests.
+// Each output is decoded through a type without methods so its UnmarshalJSON doesn't call itself.
+
+type listsListOutput ListsListOutput
+
+// UnmarshalJSON will decode the list and keep the original JSON in Raw
+func (o *ListsListOutput) UnmarshalJSON(data []byte) error {
+	err := json.Unmarshal(data, (*listsListOutput)(o))
+	if err != nil {
+		return err
+	}
+
+	o.Raw = copyRaw(data)
+
+	return nil
+}
+
+type listsMembersOutput ListsMembersOutput
+
+// UnmarshalJSON will decode the list members and keep the original JSON in Raw
+func (o *ListsMembersOutput) UnmarshalJSON(data []byte) error {
+	err := json.Unmarshal(data, (*listsMembersOutput)(o))
+	if err != nil {
+		return err
+	}
+
+	o.Raw = copyRaw(data)
+
+	return nil
+}
+
+type listsMembersShowOutput ListsMembersShowOutput
+
+// UnmarshalJSON will decode the user and keep the original JSON in Raw
+func (o *ListsMembersShowOutput) UnmarshalJSON(data []byte) error {
+	err := json.Unmarshal(data, (*listsMembersShowOutput)(o))
+	if err != nil {
+		return err
+	}
+
+	o.Raw = copyRaw(data)
+
+	return nil
+}
+
+type searchTweetsOutput SearchTweetsOutput
+
+// UnmarshalJSON will decode the search results and keep the original JSON in Raw. The statuses are decoded without
+// their UnmarshalJSON so they don't each keep another copy of the tweets that are already in Raw.
+func (o *SearchTweetsOutput) UnmarshalJSON(data []byte) error {
+	decoded := struct {
+		*searchTweetsOutput
+		Statuses []statusesFilterOutput `json:"statuses"`
+	}{searchTweetsOutput: (*searchTweetsOutput)(o)}
+
+	err := json.Unmarshal(data, &decoded)
+	if err != nil {
+		return err
+	}
+
+	o.Statuses = nil
+	if decoded.Statuses != nil {
+		o.Statuses = make([]StatusesFilterOutput, len(decoded.Statuses))
+		for i, status := range decoded.Statuses {
+			o.Statuses[i] = StatusesFilterOutput(status)
+		}
+	}
+
+	o.Raw = copyRaw(data)
+
+	return nil
+}
+
+type statusesUpdateOutput StatusesUpdateOutput
+
+// UnmarshalJSON will decode the posted tweet and keep the original JSON in Raw
+func (o *StatusesUpdateOutput) UnmarshalJSON(data []byte) error {
+	err := json.Unmarshal(data, (*statusesUpdateOutput)(o))
+	if err != nil {
+		return err
+	}
+
+	o.Raw = copyRaw(data)
+
+	return nil
+}
+
+type statusesFilterOutput StatusesFilterOutput
+
+// UnmarshalJSON will decode the tweet along with the tweets it quotes and retweets, keeping the original JSON in Raw
+func (o *StatusesFilterOutput) UnmarshalJSON(data []byte) error {
+	err := json.Unmarshal(data, (*statusesFilterOutput)(o))
+	if err != nil {
+		return err
+	}
+
+	o.Raw = copyRaw(data)
+
+	return nil
+}
+
+type statusesUserTimelineOutput StatusesUserTimelineOutput
+
+// UnmarshalJSON will decode the tweet along with the tweets it quotes and retweets, keeping the original JSON in Raw
+func (o *StatusesUserTimelineOutput) UnmarshalJSON(data []byte) error {
+	err := json.Unmarshal(data, (*statusesUserTimelineOutput)(o))
+	if err != nil {
+		return err
+	}
+
+	o.Raw = copyRaw(data)
+
+	return nil
+}
+
+// copyRaw will copy data, which json.Unmarshal doesn't allow to be kept after UnmarshalJSON returns. Null is not
+// kept so outputs that were missing from the payload have no Raw.
+func copyRaw(data []byte) json.RawMessage {
+	if string(data) == "null" {
+		return nil
+	}
+
+	raw := make(json.RawMessage, len(data))
+	copy(raw, data)
+
+	return raw
+}
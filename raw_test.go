@@ -0,0 +1,80 @@
+package tweetgo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOutputsKeepTheOriginalJSON(t *testing.T) {
+	raw := `{"id":1,"text":"hello","new_attribute":{"enabled":true},"user":{"id":2,"new_user_attribute":1},"quoted_status":{"id":3,"text":"quoted"},"retweeted_status":null}`
+
+	output := StatusesFilterOutput{}
+	err := json.Unmarshal([]byte(raw), &output)
+	if err != nil {
+		t.Fatalf("Unmarshal failed: %s", err.Error())
+	}
+
+	if output.ID != 1 || output.Text != "hello" || output.QuotedStatus.Text != "quoted" {
+		t.Fatalf("unexpected output: %+v", output)
+	}
+
+	if string(output.Raw) != raw {
+		t.Fatalf("raw: %s != expected: %s", output.Raw, raw)
+	}
+
+	// nested tweets and users don't keep their own copy, their attributes are read from the output's Raw
+	extra := struct {
+		NewAttribute struct {
+			Enabled bool `json:"enabled"`
+		} `json:"new_attribute"`
+		User struct {
+			NewUserAttribute int `json:"new_user_attribute"`
+		} `json:"user"`
+	}{}
+	err = json.Unmarshal(output.Raw, &extra)
+	if err != nil || !extra.NewAttribute.Enabled || extra.User.NewUserAttribute != 1 {
+		t.Fatalf("new attributes could not be read from raw")
+	}
+}
+
+func TestTimelineOutputsKeepTheOriginalJSON(t *testing.T) {
+	raw := `[{"id":1,"full_text":"first","unknown":1},{"id":2,"full_text":"second"}]`
+
+	var output []StatusesUserTimelineOutput
+	err := json.Unmarshal([]byte(raw), &output)
+	if err != nil {
+		t.Fatalf("Unmarshal failed: %s", err.Error())
+	}
+
+	if len(output) != 2 || output[1].UntruncatedText() != "second" {
+		t.Fatalf("unexpected output: %+v", output)
+	}
+
+	if string(output[0].Raw) != `{"id":1,"full_text":"first","unknown":1}` {
+		t.Fatalf("raw: %s != expected: %s", output[0].Raw, `{"id":1,"full_text":"first","unknown":1}`)
+	}
+}
+
+func TestSearchOutputsOnlyKeepTheOriginalJSONOnce(t *testing.T) {
+	raw := `{"statuses":[{"id":1,"text":"first"},{"id":2,"text":"second"}],"search_metadata":{"count":2}}`
+
+	output := SearchTweetsOutput{}
+	err := json.Unmarshal([]byte(raw), &output)
+	if err != nil {
+		t.Fatalf("Unmarshal failed: %s", err.Error())
+	}
+
+	if len(output.Statuses) != 2 || output.Statuses[1].Text != "second" || output.SearchMetadata.Count != 2 {
+		t.Fatalf("unexpected output: %+v", output)
+	}
+
+	if string(output.Raw) != raw {
+		t.Fatalf("raw: %s != expected: %s", output.Raw, raw)
+	}
+
+	for _, status := range output.Statuses {
+		if status.Raw != nil {
+			t.Fatalf("status %d kept its own raw: %s", status.ID, status.Raw)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package tweetgo
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+)
+
+// StreamRecorder writes stream messages to a JSONL file, one message per line along with the time it arrived. The
+// recording can be played back with NewReplayStream.
+type StreamRecorder struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// streamRecord is a single line of a recording
+type streamRecord struct {
+	ReceivedAt time.Time       `json:"received_at"`
+	Message    json.RawMessage `json:"message"`
+}
+
+// NewStreamRecorder will create a StreamRecorder writing to w. Assign it to FilterStream.Recorder to record a stream.
+func NewStreamRecorder(w io.Writer) *StreamRecorder {
+	return &StreamRecorder{
+		w: w,
+	}
+}
+
+// Record will write a single message which arrived at receivedAt
+func (r *StreamRecorder) Record(msg []byte, receivedAt time.Time) error {
+	line, err := json.Marshal(streamRecord{
+		ReceivedAt: receivedAt,
+		Message:    msg,
+	})
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, err = r.w.Write(append(line, '\n'))
+
+	return err
+}
+
+// NewReplayStream will create a FilterStream which plays back a recording made by a StreamRecorder. When realtime is
+// true the messages are delivered with the same gaps between them as when they were recorded, otherwise they are
+// delivered as fast as they are consumed. The stream ends with io.EOF at the end of the recording.
+func NewReplayStream(r io.Reader, realtime bool) *FilterStream {
+	body, ok := r.(io.ReadCloser)
+	if !ok {
+		body = ioutil.NopCloser(r)
+	}
+
+	fs := &FilterStream{
+		body: body,
+		stop: make(chan struct{}),
+	}
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), maxRecordSize)
+
+	fs.reader = &replayReader{
+		scanner:  scanner,
+		realtime: realtime,
+		stop:     fs.stop,
+	}
+
+	return fs
+}
+
+// maxRecordSize is the longest line a recording may contain, which is far larger than any single tweet
+const maxRecordSize = 4 * 1024 * 1024
+
+// replayReader reads the messages from a recording, waiting between them when replaying in realtime
+type replayReader struct {
+	scanner  *bufio.Scanner
+	realtime bool
+	stop     <-chan struct{}
+	last     time.Time
+}
+
+func (r *replayReader) next() ([]byte, error) {
+	for r.scanner.Scan() {
+		line := r.scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		record := streamRecord{}
+		err := json.Unmarshal(line, &record)
+		if err != nil {
+			return nil, err
+		}
+
+		if r.realtime && !r.last.IsZero() {
+			wait := record.ReceivedAt.Sub(r.last)
+			if wait > 0 {
+				timer := time.NewTimer(wait)
+				select {
+				case <-r.stop:
+					timer.Stop()
+					return nil, io.EOF
+				case <-timer.C:
+				}
+			}
+		}
+		r.last = record.ReceivedAt
+
+		return record.Message, nil
+	}
+
+	err := r.scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, io.EOF
+}
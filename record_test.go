@@ -0,0 +1,60 @@
+package tweetgo
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStreamRecordingCanBeReplayed(t *testing.T) {
+	body := "{\"id\":1,\"text\":\"one\"}\r\n\r\n{\"limit\":{\"track\":5}}\r\n{\"id\":2,\"text\":\"two\"}\r\n"
+
+	recording := &bytes.Buffer{}
+	fs := NewFilterStream(newTestResponse(body), false)
+	fs.Recorder = NewStreamRecorder(recording)
+	fs.Run(func(output StatusesFilterOutput) {})
+
+	if lines := strings.Count(recording.String(), "\n"); lines != 3 {
+		t.Fatalf("recorded lines: %d != expected: 3\n%s", lines, recording.String())
+	}
+
+	var texts []string
+	var limits []int
+	replay := NewReplayStream(recording, false)
+	err := replay.RunDemux(StreamDemux{
+		Tweet: func(output StatusesFilterOutput) { texts = append(texts, output.Text) },
+		Limit: func(output StreamLimit) { limits = append(limits, output.Track) },
+	})
+	if err != io.EOF {
+		t.Fatalf("expected io.EOF at the end of the recording, got: %v", err)
+	}
+
+	if len(texts) != 2 || texts[0] != "one" || texts[1] != "two" || len(limits) != 1 || limits[0] != 5 {
+		t.Fatalf("texts: %q limits: %v, expected: [\"one\" \"two\"] [5]", texts, limits)
+	}
+}
+
+func TestReplayStreamKeepsOriginalTimingInRealtime(t *testing.T) {
+	recording := &bytes.Buffer{}
+	r := NewStreamRecorder(recording)
+
+	start := time.Now()
+	r.Record([]byte(`{"id":1}`), start)
+	r.Record([]byte(`{"id":2}`), start.Add(50*time.Millisecond))
+
+	began := time.Now()
+	count := 0
+	NewReplayStream(recording, true).Run(func(output StatusesFilterOutput) {
+		count++
+	})
+
+	if elapsed := time.Since(began); elapsed < 50*time.Millisecond {
+		t.Fatalf("replay took %s, expected at least 50ms", elapsed)
+	}
+
+	if count != 2 {
+		t.Fatalf("count: %d != expected: 2", count)
+	}
+}
@@ -0,0 +1,135 @@
+package tweetgo
+
+import (
+	"html"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// twitterURL is where rendered hashtags, mentions and cashtags link to
+const twitterURL = "https://twitter.com/"
+
+// RenderHTML will render the text of a tweet as HTML, linking every hashtag, mention, cashtag, link and media entity.
+// Links point to their expanded url rather than t.co, and for extended tweets only the text inside display_text_range
+// is rendered so the mentions that start a reply and the link to attached media are left out. The text of a retweet is
+// truncated, so render its RetweetedStatus to show the whole tweet.
+func RenderHTML(t Tweet) string {
+	return renderTweet(t, html.EscapeString, func(href, display string) string {
+		return `<a href="` + html.EscapeString(href) + `">` + html.EscapeString(display) + `</a>`
+	})
+}
+
+// RenderMarkdown will render the text of a tweet as Markdown, in the same way as RenderHTML
+func RenderMarkdown(t Tweet) string {
+	return renderTweet(t, markdownReplacer.Replace, func(href, display string) string {
+		return "[" + markdownReplacer.Replace(display) + "](" + markdownURLReplacer.Replace(href) + ")"
+	})
+}
+
+// renderSpan is an entity that will be replaced by a link. Start and end are code point offsets into the text.
+type renderSpan struct {
+	start   int
+	end     int
+	href    string
+	display string
+}
+
+func renderTweet(t Tweet, escape func(string) string, link func(href, display string) string) string {
+	text := tweetText(t)
+	entities := t.Entities
+	displayRange := t.DisplayTextRange
+
+	if t.ExtendedTweet.FullText != "" {
+		entities = t.ExtendedTweet.Entities
+		displayRange = t.ExtendedTweet.DisplayTextRange
+	}
+
+	// twitter escapes &, < and > in the text but counts indices in the unescaped text
+	runes := []rune(html.UnescapeString(text))
+
+	start, end := 0, len(runes)
+	if validIndices(displayRange, len(runes)) {
+		start, end = displayRange[0], displayRange[1]
+	}
+
+	spans := entitySpans(runes, entities)
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].start < spans[j].start
+	})
+
+	rendered := strings.Builder{}
+	pos := start
+	for _, span := range spans {
+		if span.start < pos || span.end > end {
+			continue
+		}
+
+		rendered.WriteString(escape(string(runes[pos:span.start])))
+		rendered.WriteString(link(span.href, span.display))
+		pos = span.end
+	}
+	rendered.WriteString(escape(string(runes[pos:end])))
+
+	return rendered.String()
+}
+
+// entitySpans will return a span for every entity whose indices are inside text. Entities with invalid indices are
+// ignored rather than rendering a broken link.
+func entitySpans(text []rune, entities Entities) []renderSpan {
+	var spans []renderSpan
+	add := func(indices []int, href string, display string) {
+		if !validIndices(indices, len(text)) || indices[0] == indices[1] {
+			return
+		}
+
+		if display == "" {
+			display = string(text[indices[0]:indices[1]])
+		}
+
+		spans = append(spans, renderSpan{start: indices[0], end: indices[1], href: href, display: display})
+	}
+
+	for _, hashtag := range entities.Hashtags {
+		add(hashtag.Indices, twitterURL+"hashtag/"+url.PathEscape(hashtag.Text), "")
+	}
+
+	for _, mention := range entities.UserMentions {
+		add(mention.Indices, twitterURL+mention.ScreenName, "")
+	}
+
+	for _, symbol := range entities.Symbols {
+		add(symbol.Indices, twitterURL+"search?q="+url.QueryEscape("$"+symbol.Text), "")
+	}
+
+	for _, u := range entities.URLs {
+		add(u.Indices, firstNonEmpty(u.ExpandedURL, u.URL), firstNonEmpty(u.DisplayURL, u.URL))
+	}
+
+	for _, media := range entities.Media {
+		add(media.Indices, firstNonEmpty(media.ExpandedURL, media.URL), firstNonEmpty(media.DisplayURL, media.URL))
+	}
+
+	return spans
+}
+
+// validIndices will report whether indices is a start and end offset within a text of length code points
+func validIndices(indices []int, length int) bool {
+	return len(indices) == 2 && indices[0] >= 0 && indices[0] <= indices[1] && indices[1] <= length
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+var markdownReplacer = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`, `<`, `\<`, `>`, `\>`, `#`, `\#`,
+)
+
+var markdownURLReplacer = strings.NewReplacer(`(`, `%28`, `)`, `%29`, ` `, `%20`)
@@ -0,0 +1,65 @@
+package tweetgo
+
+import "testing"
+
+const renderTestTweet = `{
+	"text": "😀 #Go &amp; <b>@gopher</b> $TWTR https://t.co/abc",
+	"entities": {
+		"hashtags": [{"text": "Go", "indices": [2, 5]}],
+		"user_mentions": [{"screen_name": "gopher", "indices": [11, 18]}],
+		"symbols": [{"text": "TWTR", "indices": [23, 28]}],
+		"urls": [{"url": "https://t.co/abc", "expanded_url": "https://golang.org/doc?a=1&b=2", "display_url": "golang.org/doc?a=1…", "indices": [29, 45]}]
+	}
+}`
+
+func TestRenderHTMLLinksEntitiesUsingCodePointIndices(t *testing.T) {
+	rendered := RenderHTML(decodeTestOutput(t, renderTestTweet).Tweet)
+
+	expected := `😀 <a href="https://twitter.com/hashtag/Go">#Go</a> &amp; &lt;b&gt;` +
+		`<a href="https://twitter.com/gopher">@gopher</a>&lt;/b&gt; ` +
+		`<a href="https://twitter.com/search?q=%24TWTR">$TWTR</a> ` +
+		`<a href="https://golang.org/doc?a=1&amp;b=2">golang.org/doc?a=1…</a>`
+
+	if rendered != expected {
+		t.Fatalf("rendered: %s != expected: %s", rendered, expected)
+	}
+}
+
+func TestRenderMarkdownLinksEntities(t *testing.T) {
+	rendered := RenderMarkdown(decodeTestOutput(t, renderTestTweet).Tweet)
+
+	expected := `😀 [\#Go](https://twitter.com/hashtag/Go) & \<b\>[@gopher](https://twitter.com/gopher)\</b\> ` +
+		`[$TWTR](https://twitter.com/search?q=%24TWTR) [golang.org/doc?a=1…](https://golang.org/doc?a=1&b=2)`
+
+	if rendered != expected {
+		t.Fatalf("rendered: %s != expected: %s", rendered, expected)
+	}
+}
+
+func TestRenderHonorsDisplayTextRange(t *testing.T) {
+	tweet := decodeTestOutput(t, `{
+		"text": "@gopher @golang short…",
+		"extended_tweet": {
+			"full_text": "@gopher @golang thanks for #go https://t.co/media",
+			"display_text_range": [16, 30],
+			"entities": {
+				"hashtags": [{"text": "go", "indices": [27, 30]}],
+				"user_mentions": [{"screen_name": "gopher", "indices": [0, 7]}, {"screen_name": "golang", "indices": [8, 15]}],
+				"media": [{"url": "https://t.co/media", "expanded_url": "https://twitter.com/gopher/status/1/photo/1", "display_url": "pic.twitter.com/media", "indices": [31, 49]}]
+			}
+		}
+	}`).Tweet
+
+	expected := `thanks for <a href="https://twitter.com/hashtag/go">#go</a>`
+	if rendered := RenderHTML(tweet); rendered != expected {
+		t.Fatalf("rendered: %s != expected: %s", rendered, expected)
+	}
+}
+
+func TestRenderIgnoresInvalidIndices(t *testing.T) {
+	tweet := decodeTestOutput(t, `{"text": "#go", "entities": {"hashtags": [{"text": "go", "indices": [0, 40]}]}}`).Tweet
+
+	if rendered := RenderHTML(tweet); rendered != "#go" {
+		t.Fatalf("rendered: %s != expected: #go", rendered)
+	}
+}
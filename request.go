@@ -1,9 +1,13 @@
 package tweetgo
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
 	"crypto/hmac"
 	"crypto/sha1"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"io"
 	"io/ioutil"
@@ -12,6 +16,8 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 type requestMaker interface {
@@ -58,6 +64,8 @@ func processParams(input interface{}) url.Values {
 				params.Add(name, strconv.FormatInt(int64(value), 10))
 			case int64:
 				params.Add(name, strconv.FormatInt(value, 10))
+			case ID:
+				params.Add(name, value.String())
 			case float64:
 				params.Add(name, strconv.FormatFloat(value, 'f', -1, 64))
 			}
@@ -67,30 +75,166 @@ func processParams(input interface{}) url.Values {
 	return params
 }
 
+// HTTPError is returned when twitter responds with any status other than 200 OK
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+func (e *HTTPError) Error() string {
+	return "Status: " + e.Status + " - Body: " + e.Body
+}
+
+// errNoResponse is returned when a middleware ends the chain without a response or an error
+var errNoResponse = errors.New("middleware returned neither a response nor an error")
+
 func (c Client) executeRequest(method, uri string, params url.Values) (*http.Response, error) {
+	return c.execute(context.Background(), method, uri, params, true)
+}
+
+// executeStreamRequest is executeRequest for streaming endpoints, whose bodies are never passed to OnResponse. The
+// connection is closed when ctx is cancelled.
+func (c Client) executeStreamRequest(ctx context.Context, method, uri string, params url.Values) (*http.Response, error) {
+	return c.execute(ctx, method, uri, params, false)
+}
+
+func (c Client) execute(ctx context.Context, method, uri string, params url.Values, observeBody bool) (*http.Response, error) {
 	req, err := c.getSignedRequest(method, uri, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
-	res, err := c.HTTPClient.Do(req)
+	start := time.Now()
+	res, err := c.chain()(req)
 	if err != nil {
 		return nil, err
 	}
 
-	if res.StatusCode != 200 {
-		b, _ := ioutil.ReadAll(res.Body)
-		return nil, errors.New("Status: " + res.Status + " - Body: " + string(b))
+	if res == nil {
+		return nil, errNoResponse
+	}
+
+	observe := func(body []byte) {
+		c.OnResponse(Response{
+			Method:     req.Method,
+			URL:        req.URL.String(),
+			StatusCode: res.StatusCode,
+			Status:     res.Status,
+			Header:     res.Header,
+			Duration:   time.Since(start),
+			Body:       body,
+		})
 	}
 
 	if res.StatusCode != http.StatusOK {
-		errBody, _ := ioutil.ReadAll(res.Body)
-		return nil, errors.New(string(errBody))
+		b, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+
+		if c.OnResponse != nil {
+			observe(b)
+		}
+
+		return nil, &HTTPError{
+			StatusCode: res.StatusCode,
+			Status:     res.Status,
+			Body:       string(b),
+		}
+	}
+
+	if c.OnResponse != nil {
+		if observeBody {
+			res.Body = &observedBody{ReadCloser: res.Body, onClose: observe}
+		} else {
+			observe(nil)
+		}
+	}
+
+	return res, nil
+}
+
+// roundTrip is the end of the middleware chain which sends the request
+func (c Client) roundTrip(req *http.Request) (*http.Response, error) {
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Header.Get("Content-Encoding") == "gzip" {
+		body, err := newGzipBody(res.Body)
+		switch {
+		case err == nil:
+			res.Body = body
+		case res.StatusCode != http.StatusOK:
+			// the body of an error is only informative, so one that can't be decompressed mustn't hide the status
+			res.Body.Close()
+			res.Body = http.NoBody
+		default:
+			res.Body.Close()
+			return nil, err
+		}
+
+		res.Header.Del("Content-Encoding")
+		res.Header.Del("Content-Length")
+		res.ContentLength = -1
 	}
 
 	return res, nil
 }
 
+// gzipBody decompresses a response body. Twitter flushes the compressed stream after every message and the gzip reader
+// returns data as soon as a flushed block has been decompressed, so streamed messages aren't held back.
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func newGzipBody(body io.ReadCloser) (*gzipBody, error) {
+	reader, err := gzip.NewReader(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gzipBody{
+		Reader: reader,
+		body:   body,
+	}, nil
+}
+
+func (g *gzipBody) Close() error {
+	g.Reader.Close()
+	return g.body.Close()
+}
+
+// maxPooledBuffer is the largest response buffer kept for reuse, so one huge response doesn't stay in memory forever
+const maxPooledBuffer = 4 << 20
+
+var bodyBuffers = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// decodeBody will read a JSON response body into a pooled buffer and decode it into output. Reusing the buffer saves
+// allocating a new one, and growing it, for every response.
+func decodeBody(body io.Reader, output interface{}) error {
+	buf := bodyBuffers.Get().(*bytes.Buffer)
+	defer func() {
+		if buf.Cap() <= maxPooledBuffer {
+			buf.Reset()
+			bodyBuffers.Put(buf)
+		}
+	}()
+
+	_, err := buf.ReadFrom(body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(buf.Bytes(), output)
+}
+
 func bodyToValues(body io.ReadCloser) (url.Values, error) {
 	bodyBytes, err := ioutil.ReadAll(body)
 	if err != nil {
@@ -152,6 +296,7 @@ func (c Client) getSignedRequest(method, uri string, params url.Values) (*http.R
 
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Add("Authorization", authHeader)
+	req.Header.Add("Accept-Encoding", "gzip")
 
 	return req, nil
 }
@@ -1,11 +1,18 @@
 package tweetgo
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestCanProcessParamsAndOmitNilValues(t *testing.T) {
@@ -180,3 +187,133 @@ func TestCorrectlyCalculatesSignatureForStatusesUpdateWithDuplicatedGetParameter
 		t.Fatalf("sig: %s != expected: %s", sig, expected)
 	}
 }
+
+// newFakeGzipClient will create a fakeClient which answers with body as a gzip compressed response
+func newFakeGzipClient(statusCode int, body io.ReadCloser) *fakeClient {
+	return &fakeClient{
+		respond: func(req *http.Request) (*http.Response, error) {
+			res := newTestStatusResponse(statusCode, "")
+			res.Header.Set("Content-Encoding", "gzip")
+			res.Body = body
+
+			return res, nil
+		},
+	}
+}
+
+func TestRequestsAcceptGzipAndDecompressResponses(t *testing.T) {
+	compressed := &bytes.Buffer{}
+	zw := gzip.NewWriter(compressed)
+	zw.Write([]byte(`[{"id":1,"text":"compressed"}]`))
+	zw.Close()
+
+	fake := newFakeGzipClient(http.StatusOK, ioutil.NopCloser(compressed))
+	tc := NewClient("key", "secret")
+	tc.HTTPClient = fake
+
+	output, err := tc.StatusesUserTimelineGet(StatusesUserTimelineInput{})
+	if err != nil {
+		t.Fatalf("StatusesUserTimelineGet failed: %s", err.Error())
+	}
+
+	if encoding := fake.sent()[0].Header.Get("Accept-Encoding"); encoding != "gzip" {
+		t.Fatalf("Accept-Encoding: %q != expected: gzip", encoding)
+	}
+
+	if len(output) != 1 || output[0].Text != "compressed" {
+		t.Fatalf("unexpected output: %+v", output)
+	}
+}
+
+func TestGzipErrorsWithoutABodyKeepTheirStatus(t *testing.T) {
+	tc := NewClient("key", "secret")
+	tc.HTTPClient = newFakeGzipClient(420, ioutil.NopCloser(&bytes.Buffer{}))
+
+	_, err := tc.StatusesFilterStream(StatusesFilterInput{Track: String("golang")})
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != 420 {
+		t.Fatalf("err: %v != expected: an HTTPError with status 420", err)
+	}
+}
+
+func TestGzipStreamsDeliverEachMessageAsItIsFlushed(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	zw := gzip.NewWriter(pw)
+	flushed := make(chan struct{})
+	go func() {
+		zw.Write([]byte("{\"id\":1,\"text\":\"first\"}\r\n"))
+		zw.Flush()
+		close(flushed)
+	}()
+
+	tc := NewClient("key", "secret")
+	tc.HTTPClient = newFakeGzipClient(http.StatusOK, pr)
+
+	fs, err := tc.StatusesFilterStream(StatusesFilterInput{Track: String("golang")})
+	if err != nil {
+		t.Fatalf("StatusesFilterStream failed: %s", err.Error())
+	}
+	defer fs.Stop()
+
+	select {
+	case output := <-fs.Messages():
+		if output.Text != "first" {
+			t.Fatalf("text: %s != expected: first", output.Text)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("the flushed message was never delivered")
+	}
+
+	<-flushed
+}
+
+func benchmarkTimelineBody(b *testing.B) []byte {
+	raw, err := ioutil.ReadFile("testdata/tweet_withheld.json")
+	if err != nil {
+		b.Fatalf("could not read fixture: %s", err.Error())
+	}
+
+	tweets := make([][]byte, 200)
+	for i := range tweets {
+		tweets[i] = raw
+	}
+
+	return append(append([]byte("["), bytes.Join(tweets, []byte(","))...), ']')
+}
+
+// BenchmarkReadAllAndUnmarshal is how responses were decoded before decodeBody, kept for comparison
+func BenchmarkReadAllAndUnmarshal(b *testing.B) {
+	body := benchmarkTimelineBody(b)
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		resBytes, err := ioutil.ReadAll(bytes.NewReader(body))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		var output []StatusesUserTimelineOutput
+		err = json.Unmarshal(resBytes, &output)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeBody(b *testing.B) {
+	body := benchmarkTimelineBody(b)
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		var output []StatusesUserTimelineOutput
+		err := decodeBody(bytes.NewReader(body), &output)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
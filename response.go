@@ -0,0 +1,75 @@
+package tweetgo
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Response describes a request made by the client, for auditing or for reading headers which aren't part of the
+// endpoint's output. Set Client.OnResponse to receive one for every request.
+type Response struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Status     string
+	Header     http.Header
+	// Duration is the time from sending the request until the body was closed. For streams it is the time until the
+	// connection was opened.
+	Duration time.Duration
+	// Body is the raw, decompressed, response body. It is nil for streams because their bodies never end.
+	Body []byte
+}
+
+// AccessLevel will return the access level of the token used for the request, from the x-access-level header, such as
+// "read" or "read-write"
+func (r Response) AccessLevel() string {
+	return r.Header.Get("X-Access-Level")
+}
+
+// TransactionID will return the id twitter assigned to the request, from the x-transaction header
+func (r Response) TransactionID() string {
+	return r.Header.Get("X-Transaction")
+}
+
+// ResponseTime will return how long twitter took to handle the request, from the x-response-time header, or zero when
+// the header is missing
+func (r Response) ResponseTime() time.Duration {
+	ms, err := strconv.Atoi(r.Header.Get("X-Response-Time"))
+	if err != nil {
+		return 0
+	}
+
+	return time.Duration(ms) * time.Millisecond
+}
+
+// observedBody keeps a copy of everything read from a response body and reports it once the body is closed. Whatever
+// wasn't read before Close is read then, so the whole body is always reported.
+type observedBody struct {
+	io.ReadCloser
+	buf     bytes.Buffer
+	closed  bool
+	onClose func(body []byte)
+}
+
+func (o *observedBody) Read(p []byte) (int, error) {
+	n, err := o.ReadCloser.Read(p)
+	o.buf.Write(p[:n])
+
+	return n, err
+}
+
+func (o *observedBody) Close() error {
+	if o.closed {
+		return o.ReadCloser.Close()
+	}
+
+	o.closed = true
+	io.Copy(&o.buf, o.ReadCloser)
+	err := o.ReadCloser.Close()
+	o.onClose(o.buf.Bytes())
+
+	return err
+}
@@ -0,0 +1,120 @@
+package tweetgo
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newFakeResponseClient will create a fakeClient which answers with statusCode, body and twitter's metadata headers
+func newFakeResponseClient(statusCode int, body string) *fakeClient {
+	return &fakeClient{
+		respond: func(req *http.Request) (*http.Response, error) {
+			res := newTestStatusResponse(statusCode, body)
+			res.Header.Set("X-Access-Level", "read-write")
+			res.Header.Set("X-Transaction", "abc123")
+			res.Header.Set("X-Response-Time", "42")
+
+			return res, nil
+		},
+	}
+}
+
+func TestOnResponseReceivesTheRawResponse(t *testing.T) {
+	body := `{"statuses":[{"id":1,"text":"hello"}],"search_metadata":{"count":1}}`
+
+	var responses []Response
+	tc := NewClient("key", "secret")
+	tc.HTTPClient = newFakeResponseClient(http.StatusOK, body)
+	tc.OnResponse = func(res Response) {
+		responses = append(responses, res)
+	}
+
+	output, err := tc.SearchTweetsGet(SearchTweetsInput{Q: String("hello")})
+	if err != nil {
+		t.Fatalf("SearchTweetsGet failed: %s", err.Error())
+	}
+
+	if len(output.Statuses) != 1 || output.Statuses[0].Text != "hello" {
+		t.Fatalf("unexpected output: %+v", output)
+	}
+
+	if len(responses) != 1 {
+		t.Fatalf("responses: %d != expected: 1", len(responses))
+	}
+
+	res := responses[0]
+	if res.Method != http.MethodGet || res.URL != "https://api.twitter.com/1.1/search/tweets.json?q=hello" {
+		t.Fatalf("request: %s %s != expected: GET https://api.twitter.com/1.1/search/tweets.json?q=hello", res.Method, res.URL)
+	}
+
+	if res.StatusCode != http.StatusOK || string(res.Body) != body {
+		t.Fatalf("status: %d, body: %s != expected: 200, %s", res.StatusCode, res.Body, body)
+	}
+
+	if res.AccessLevel() != "read-write" || res.TransactionID() != "abc123" || res.ResponseTime() != 42*time.Millisecond {
+		t.Fatalf("access level: %s, transaction: %s, response time: %s", res.AccessLevel(), res.TransactionID(), res.ResponseTime())
+	}
+}
+
+func TestOnResponseReceivesErrorResponses(t *testing.T) {
+	var responses []Response
+	tc := NewClient("key", "secret")
+	tc.HTTPClient = newFakeResponseClient(http.StatusUnauthorized, `{"errors":[{"code":32}]}`)
+	tc.OnResponse = func(res Response) {
+		responses = append(responses, res)
+	}
+
+	_, err := tc.StatusesUserTimelineGet(StatusesUserTimelineInput{})
+	if err == nil {
+		t.Fatalf("expected an error for a 401 response")
+	}
+
+	if len(responses) != 1 || responses[0].StatusCode != http.StatusUnauthorized || string(responses[0].Body) != `{"errors":[{"code":32}]}` {
+		t.Fatalf("unexpected responses: %+v", responses)
+	}
+}
+
+func TestOnResponseDoesNotRecordStreamBodies(t *testing.T) {
+	var responses []Response
+	tc := NewClient("key", "secret")
+	tc.HTTPClient = newFakeResponseClient(http.StatusOK, "{\"id\":1,\"text\":\"hi\"}\r\n")
+	tc.OnResponse = func(res Response) {
+		responses = append(responses, res)
+	}
+
+	fs, err := tc.StatusesFilterStream(StatusesFilterInput{Track: String("hi")})
+	if err != nil {
+		t.Fatalf("StatusesFilterStream failed: %s", err.Error())
+	}
+	defer fs.Stop()
+
+	if len(responses) != 1 || responses[0].Body != nil || responses[0].TransactionID() != "abc123" {
+		t.Fatalf("unexpected responses: %+v", responses)
+	}
+}
+
+func TestObservedBodyReportsTheUnreadRest(t *testing.T) {
+	var reported []byte
+	body := &observedBody{
+		ReadCloser: ioutil.NopCloser(strings.NewReader("{\"id\":1}\n")),
+		onClose: func(b []byte) {
+			reported = b
+		},
+	}
+
+	p := make([]byte, 3)
+	_, err := body.Read(p)
+	if err != nil {
+		t.Fatalf("Read failed: %s", err.Error())
+	}
+
+	body.Close()
+	body.Close()
+
+	if string(reported) != "{\"id\":1}\n" {
+		t.Fatalf("reported body: %q != expected: %q", reported, "{\"id\":1}\n")
+	}
+}
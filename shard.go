@@ -0,0 +1,440 @@
+package tweetgo
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultDedupeWindow is how many of the most recent tweet ids are remembered when removing duplicates
+const DefaultDedupeWindow = 10000
+
+// ShardedFilterStream spreads a track and follow set that is too large for a single connection over several filter
+// streams, possibly opened by different accounts, and merges them back into one channel. A tweet matching phrases in
+// more than one shard is only delivered once.
+type ShardedFilterStream struct {
+	// Configure is called with every shard's stream before it is read, for example to set OnReconnect. Shards are
+	// created with Reconnect enabled.
+	Configure func(*FilterStream)
+	// OnShardError is called when a shard's stream ends with an error
+	OnShardError func(error)
+
+	clients  []Client
+	messages chan StatusesFilterOutput
+	seen     *idWindow
+
+	// updating serializes calls to Update, so the shards only change underneath one while it is connecting when a
+	// shard's stream ends
+	updating sync.Mutex
+
+	mu      sync.Mutex
+	shards  []filterShard
+	stopped bool
+	stop    chan struct{}
+	wg      sync.WaitGroup
+}
+
+type filterShard struct {
+	client int
+	track  []string
+	follow []ID
+	stream *FilterStream
+}
+
+// NewShardedFilterStream will create a ShardedFilterStream which opens its shards using clients. Twitter only allows
+// one stream per account, so every shard needs its own client. Nothing is connected until the first call to Update.
+func NewShardedFilterStream(clients []Client, dedupeWindow int) *ShardedFilterStream {
+	if dedupeWindow <= 0 {
+		dedupeWindow = DefaultDedupeWindow
+	}
+
+	return &ShardedFilterStream{
+		clients:  clients,
+		messages: make(chan StatusesFilterOutput),
+		seen:     newIDWindow(dedupeWindow),
+		stop:     make(chan struct{}),
+	}
+}
+
+// Messages will return the merged channel of tweets from every shard. It is closed once Stop has been called and
+// every shard has finished.
+func (s *ShardedFilterStream) Messages() <-chan StatusesFilterOutput {
+	return s.messages
+}
+
+// Update will change the phrases and user ids being streamed. Shards whose phrases and user ids don't change keep
+// their connection, new terms fill up existing shards before new shards are opened, and a changed shard connects its
+// replacement before the old connection is closed. If any connection fails, or there are more shards than clients,
+// nothing is changed.
+func (s *ShardedFilterStream) Update(track []string, follow []ID) error {
+	if len(s.clients) == 0 {
+		return errors.New("sharded filter stream requires at least one client")
+	}
+
+	for _, phrase := range track {
+		_, err := FilterTrack(phrase)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, userID := range follow {
+		_, err := FilterFollow(userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.updating.Lock()
+	defer s.updating.Unlock()
+
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return errors.New("sharded filter stream has been stopped")
+	}
+	current := append([]filterShard(nil), s.shards...)
+	s.mu.Unlock()
+
+	planned, changed := planShards(current, track, follow, len(s.clients))
+
+	// each account may only have one stream open at a time
+	if openShards(planned) > len(s.clients) {
+		return errors.New("sharded filter stream needs " + strconv.Itoa(openShards(planned)) + " shards but only has " +
+			strconv.Itoa(len(s.clients)) + " clients")
+	}
+
+	// connect every changed shard first, without holding the lock, so a failure leaves the current shards untouched
+	var connected []*FilterStream
+	stopConnected := func() {
+		for _, fs := range connected {
+			fs.Stop()
+		}
+	}
+
+	for i := range planned {
+		if !changed[i] || (len(planned[i].track) == 0 && len(planned[i].follow) == 0) {
+			continue
+		}
+
+		fs, err := s.connect(planned[i])
+		if err != nil {
+			stopConnected()
+			return err
+		}
+
+		planned[i].stream = fs
+		connected = append(connected, fs)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		stopConnected()
+		return errors.New("sharded filter stream has been stopped")
+	}
+
+	for i, shard := range current {
+		if changed[i] {
+			shard.stream.Stop()
+		}
+	}
+
+	// a shard that was kept may have ended while the others were connecting
+	open := map[*FilterStream]bool{}
+	for _, shard := range s.shards {
+		open[shard.stream] = true
+	}
+
+	s.shards = s.shards[:0]
+	for i, shard := range planned {
+		if len(shard.track) == 0 && len(shard.follow) == 0 {
+			continue
+		}
+
+		if changed[i] {
+			s.wg.Add(1)
+			go s.run(shard.stream)
+		} else if !open[shard.stream] {
+			continue
+		}
+
+		s.shards = append(s.shards, shard)
+	}
+
+	return nil
+}
+
+// Shards will return the number of connections currently open
+func (s *ShardedFilterStream) Shards() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.shards)
+}
+
+// Stop will close every shard. Messages is closed once they have all finished.
+func (s *ShardedFilterStream) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return
+	}
+
+	s.stopped = true
+	close(s.stop)
+
+	for _, shard := range s.shards {
+		shard.stream.Stop()
+	}
+	s.shards = nil
+
+	go func() {
+		s.wg.Wait()
+		close(s.messages)
+	}()
+}
+
+func (s *ShardedFilterStream) connect(shard filterShard) (*FilterStream, error) {
+	input := StatusesFilterInput{}
+
+	if len(shard.track) > 0 {
+		track, err := FilterTrack(shard.track...)
+		if err != nil {
+			return nil, err
+		}
+		input.Track = track
+	}
+
+	if len(shard.follow) > 0 {
+		follow, err := FilterFollow(shard.follow...)
+		if err != nil {
+			return nil, err
+		}
+		input.Follow = follow
+	}
+
+	fs, err := s.clients[shard.client].StatusesFilterStream(input)
+	if err != nil {
+		return nil, err
+	}
+
+	fs.Reconnect = true
+	if s.Configure != nil {
+		s.Configure(fs)
+	}
+
+	return fs, nil
+}
+
+func (s *ShardedFilterStream) run(fs *FilterStream) {
+	defer s.wg.Done()
+
+	err := fs.Run(func(output StatusesFilterOutput) {
+		if !s.seen.add(output.ID) {
+			return
+		}
+
+		select {
+		case s.messages <- output:
+		case <-s.stop:
+		}
+	})
+
+	// a shard only ends on its own when its error can't be retried, so it no longer counts as open
+	s.mu.Lock()
+	for i, shard := range s.shards {
+		if shard.stream == fs {
+			s.shards = append(s.shards[:i], s.shards[i+1:]...)
+			break
+		}
+	}
+	s.mu.Unlock()
+
+	if err != nil && s.OnShardError != nil {
+		s.OnShardError(err)
+	}
+}
+
+// planShards will assign track phrases and follow ids to shards, keeping existing assignments where possible. The
+// returned shards line up with current, followed by any new shards, and changed reports which of them need to
+// reconnect.
+func planShards(current []filterShard, track []string, follow []ID, clients int) ([]filterShard, []bool) {
+	wantTrack := map[string]bool{}
+	var newTrack []string
+	for _, phrase := range track {
+		phrase = strings.Join(strings.Fields(strings.ToLower(phrase)), " ")
+		if !wantTrack[phrase] {
+			wantTrack[phrase] = true
+			newTrack = append(newTrack, phrase)
+		}
+	}
+
+	wantFollow := map[ID]bool{}
+	var newFollow []ID
+	for _, userID := range follow {
+		if !wantFollow[userID] {
+			wantFollow[userID] = true
+			newFollow = append(newFollow, userID)
+		}
+	}
+
+	planned := make([]filterShard, len(current))
+	changed := make([]bool, len(current))
+	assignedTrack := map[string]bool{}
+	assignedFollow := map[ID]bool{}
+
+	// drop anything no longer wanted from the existing shards
+	for i, shard := range current {
+		planned[i] = filterShard{client: shard.client, stream: shard.stream}
+
+		for _, phrase := range shard.track {
+			if wantTrack[phrase] {
+				planned[i].track = append(planned[i].track, phrase)
+				assignedTrack[phrase] = true
+			} else {
+				changed[i] = true
+			}
+		}
+
+		for _, userID := range shard.follow {
+			if wantFollow[userID] {
+				planned[i].follow = append(planned[i].follow, userID)
+				assignedFollow[userID] = true
+			} else {
+				changed[i] = true
+			}
+		}
+	}
+
+	// fill shards that are already reconnecting before disturbing any others
+	order := make([]int, 0, len(planned))
+	for i := range planned {
+		if changed[i] {
+			order = append(order, i)
+		}
+	}
+	for i := range planned {
+		if !changed[i] {
+			order = append(order, i)
+		}
+	}
+
+	for _, phrase := range newTrack {
+		if assignedTrack[phrase] {
+			continue
+		}
+
+		i := shardWithRoom(planned, order, func(s filterShard) bool { return len(s.track) < MaxTrackKeywords })
+		if i < 0 {
+			planned = append(planned, filterShard{client: leastUsedClient(planned, clients)})
+			changed = append(changed, true)
+			i = len(planned) - 1
+			order = append(order, i)
+		}
+
+		planned[i].track = append(planned[i].track, phrase)
+		changed[i] = true
+	}
+
+	for _, userID := range newFollow {
+		if assignedFollow[userID] {
+			continue
+		}
+
+		i := shardWithRoom(planned, order, func(s filterShard) bool { return len(s.follow) < MaxFollowUserIDs })
+		if i < 0 {
+			planned = append(planned, filterShard{client: leastUsedClient(planned, clients)})
+			changed = append(changed, true)
+			i = len(planned) - 1
+			order = append(order, i)
+		}
+
+		planned[i].follow = append(planned[i].follow, userID)
+		changed[i] = true
+	}
+
+	return planned, changed
+}
+
+func openShards(shards []filterShard) int {
+	open := 0
+	for _, shard := range shards {
+		if len(shard.track) > 0 || len(shard.follow) > 0 {
+			open++
+		}
+	}
+
+	return open
+}
+
+func shardWithRoom(shards []filterShard, order []int, hasRoom func(filterShard) bool) int {
+	for _, i := range order {
+		if hasRoom(shards[i]) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func leastUsedClient(shards []filterShard, clients int) int {
+	used := make([]int, clients)
+	for _, shard := range shards {
+		used[shard.client]++
+	}
+
+	least := 0
+	for i := range used {
+		if used[i] < used[least] {
+			least = i
+		}
+	}
+
+	return least
+}
+
+// idWindow remembers the most recent ids it has seen, forgetting the oldest once it is full
+type idWindow struct {
+	mu    sync.Mutex
+	ids   map[ID]struct{}
+	order []ID
+	next  int
+}
+
+func newIDWindow(size int) *idWindow {
+	return &idWindow{
+		ids:   make(map[ID]struct{}, size),
+		order: make([]ID, 0, size),
+	}
+}
+
+// add will return false if id is already in the window. A missing id is never a duplicate, since every tweet without
+// one would match.
+func (w *idWindow) add(id ID) bool {
+	if id == 0 {
+		return true
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.ids[id]; ok {
+		return false
+	}
+
+	if len(w.order) < cap(w.order) {
+		w.order = append(w.order, id)
+	} else {
+		delete(w.ids, w.order[w.next])
+		w.order[w.next] = id
+		w.next = (w.next + 1) % len(w.order)
+	}
+
+	w.ids[id] = struct{}{}
+
+	return true
+}
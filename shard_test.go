@@ -0,0 +1,181 @@
+package tweetgo
+
+import (
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testPhrases(prefix string, count int) []string {
+	phrases := make([]string, count)
+	for i := range phrases {
+		phrases[i] = prefix + strconv.Itoa(i)
+	}
+
+	return phrases
+}
+
+func TestPlanShardsSplitsLargeTrackSets(t *testing.T) {
+	planned, changed := planShards(nil, testPhrases("keyword", MaxTrackKeywords+10), []ID{1, 2}, 2)
+
+	if len(planned) != 2 {
+		t.Fatalf("shards: %d != expected: 2", len(planned))
+	}
+
+	if len(planned[0].track) != MaxTrackKeywords || len(planned[1].track) != 10 {
+		t.Fatalf("track sizes: %d, %d != expected: %d, 10", len(planned[0].track), len(planned[1].track), MaxTrackKeywords)
+	}
+
+	if len(planned[0].follow) != 2 || planned[0].client != 0 || planned[1].client != 1 {
+		t.Fatalf("unexpected shards: %+v", planned)
+	}
+
+	if !changed[0] || !changed[1] {
+		t.Fatalf("expected every new shard to be changed: %v", changed)
+	}
+}
+
+func TestPlanShardsOnlyChangesAffectedShards(t *testing.T) {
+	track := testPhrases("keyword", MaxTrackKeywords+10)
+	current, _ := planShards(nil, track, nil, 1)
+
+	// remove one phrase from the second shard and add a new one, the full first shard must be left alone
+	track = append(track[:len(track)-1], "brand new")
+	planned, changed := planShards(current, track, nil, 1)
+
+	if len(planned) != 2 || changed[0] || !changed[1] {
+		t.Fatalf("shards: %d changed: %v, expected 2 shards with only the second changed", len(planned), changed)
+	}
+
+	if last := planned[1].track[len(planned[1].track)-1]; last != "brand new" {
+		t.Fatalf("last phrase: %s != expected: brand new", last)
+	}
+}
+
+func TestIDWindowForgetsOldestIDs(t *testing.T) {
+	w := newIDWindow(2)
+
+	if !w.add(1) || !w.add(2) || w.add(1) {
+		t.Fatalf("expected 1 and 2 to be added once")
+	}
+
+	if !w.add(3) || !w.add(1) {
+		t.Fatalf("expected 1 to be forgotten once the window was full")
+	}
+}
+
+func TestIDWindowNeverTreatsMissingIDsAsDuplicates(t *testing.T) {
+	w := newIDWindow(10)
+	if !w.add(0) || !w.add(0) {
+		t.Fatalf("tweets without an id should never be treated as duplicates")
+	}
+}
+
+// newFakeShardClient will create a fakeClient which streams tweets 1 and 2 to the shard tracking keyword0 and tweets
+// 2 and 3 to every other shard
+func newFakeShardClient() *fakeClient {
+	return &fakeClient{
+		respond: func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+
+			if strings.Contains(string(b), "keyword0%2C") {
+				return newTestResponse("{\"id\":1}\r\n{\"id\":2}\r\n"), nil
+			}
+
+			return newTestResponse("{\"id\":2}\r\n{\"id\":3}\r\n"), nil
+		},
+	}
+}
+
+func TestShardedFilterStreamMergesAndDedupesShards(t *testing.T) {
+	clients := make([]Client, 2)
+	for i := range clients {
+		clients[i] = NewClient("key", "secret")
+		clients[i].HTTPClient = newFakeShardClient()
+	}
+
+	s := NewShardedFilterStream(clients, 0)
+	err := s.Update(testPhrases("keyword", MaxTrackKeywords+1), nil)
+	if err != nil {
+		t.Fatalf("Update failed: %s", err.Error())
+	}
+
+	if s.Shards() != 2 {
+		t.Fatalf("shards: %d != expected: 2", s.Shards())
+	}
+
+	// the shards reconnect and send the same tweets again, which must all be removed as duplicates
+	var ids []int
+	for output := range s.Messages() {
+		ids = append(ids, int(output.ID))
+		if len(ids) == 3 {
+			s.Stop()
+		}
+	}
+
+	sort.Ints(ids)
+	if len(ids) != 3 || ids[0] != 1 || ids[1] != 2 || ids[2] != 3 {
+		t.Fatalf("ids: %v != expected: [1 2 3]", ids)
+	}
+}
+
+func TestShardedFilterStreamRequiresAClientPerShard(t *testing.T) {
+	tc := NewClient("key", "secret")
+	fake := newFakeShardClient()
+	tc.HTTPClient = fake
+
+	s := NewShardedFilterStream([]Client{tc}, 0)
+	defer s.Stop()
+
+	err := s.Update(testPhrases("keyword", MaxTrackKeywords+1), nil)
+	if err == nil {
+		t.Fatalf("expected an error when there are more shards than clients")
+	}
+
+	if s.Shards() != 0 || len(fake.sent()) != 0 {
+		t.Fatalf("shards: %d requests: %d, expected nothing to be connected", s.Shards(), len(fake.sent()))
+	}
+}
+
+func TestShardedFilterStreamRemovesShardsThatCannotReconnect(t *testing.T) {
+	fake := &fakeClient{}
+	fake.respond = func(req *http.Request) (*http.Response, error) {
+		if len(fake.sent()) == 1 {
+			return newTestResponse("{\"id\":1}\r\n"), nil
+		}
+
+		return newTestStatusResponse(http.StatusUnauthorized, "{}"), nil
+	}
+
+	tc := NewClient("key", "secret")
+	tc.HTTPClient = fake
+
+	s := NewShardedFilterStream([]Client{tc}, 0)
+	defer s.Stop()
+
+	failed := make(chan error, 1)
+	s.OnShardError = func(err error) {
+		failed <- err
+	}
+
+	err := s.Update([]string{"keyword"}, nil)
+	if err != nil {
+		t.Fatalf("Update failed: %s", err.Error())
+	}
+
+	<-s.Messages()
+
+	select {
+	case <-failed:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the shard to fail once it was unauthorized")
+	}
+
+	if s.Shards() != 0 {
+		t.Fatalf("shards: %d != expected: 0", s.Shards())
+	}
+}
@@ -0,0 +1,98 @@
+// Package snowflake converts between twitter ids and the time they were created. Tweet ids, and user ids since 2013,
+// are snowflakes: the milliseconds since the twitter epoch followed by the datacenter, worker and sequence that
+// generated the id.
+// https://developer.twitter.com/en/docs/basics/twitter-ids
+package snowflake
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/bloveless/tweetgo"
+)
+
+const (
+	// Epoch is the unix time in milliseconds that snowflake timestamps count from
+	Epoch int64 = 1288834974657
+	// FirstID is the smallest tweet id generated by snowflake. Tweets and users with a smaller id were numbered
+	// sequentially and don't contain a timestamp.
+	FirstID tweetgo.ID = 29700859247
+
+	sequenceBits   = 12
+	workerBits     = 5
+	datacenterBits = 5
+	timestampShift = sequenceBits + workerBits + datacenterBits
+)
+
+// Snowflake is an id split into its parts
+type Snowflake struct {
+	Time       time.Time
+	Datacenter int
+	Worker     int
+	Sequence   int
+}
+
+// Parse will split id into its parts. Ids from before snowflake was introduced return an error.
+func Parse(id tweetgo.ID) (Snowflake, error) {
+	if !IsSnowflake(id) {
+		return Snowflake{}, errors.New("id is not a snowflake: " + id.String())
+	}
+
+	return Snowflake{
+		Time:       Time(id),
+		Datacenter: int(id>>(sequenceBits+workerBits)) & (1<<datacenterBits - 1),
+		Worker:     int(id>>sequenceBits) & (1<<workerBits - 1),
+		Sequence:   int(id) & (1<<sequenceBits - 1),
+	}, nil
+}
+
+// IsSnowflake will report whether id contains a timestamp
+func IsSnowflake(id tweetgo.ID) bool {
+	return id >= FirstID
+}
+
+// Time will return the time id was created, to the millisecond. Ids from before snowflake was introduced return the
+// zero time.
+func Time(id tweetgo.ID) time.Time {
+	if !IsSnowflake(id) {
+		return time.Time{}
+	}
+
+	ms := int64(id>>timestampShift) + Epoch
+	return time.Unix(ms/1000, (ms%1000)*int64(time.Millisecond)).UTC()
+}
+
+// MinID will return the smallest id that could have been created at t. To get every tweet created from t onwards use
+// MinID(t)-1 as a since_id, which is exclusive.
+func MinID(t time.Time) tweetgo.ID {
+	ms := t.UnixNano()/int64(time.Millisecond) - Epoch
+	if ms < 0 {
+		return 0
+	}
+
+	return tweetgo.ID(ms << timestampShift)
+}
+
+// MaxID will return the largest id that could have been created at t. It can be used as a max_id, which is inclusive,
+// to get every tweet created up to and including t.
+func MaxID(t time.Time) tweetgo.ID {
+	return MinID(t) | (1<<timestampShift - 1)
+}
+
+// Compare will compare two ids in their string form, such as id_str, returning -1 if a is smaller, 1 if a is larger
+// and 0 if they are equal. The strings are compared by their digits so ids of any size are ordered correctly, unlike
+// comparing them as strings or as float64 numbers.
+func Compare(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
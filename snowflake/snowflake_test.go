@@ -0,0 +1,78 @@
+package snowflake
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bloveless/tweetgo"
+)
+
+func TestParseSplitsAnIDIntoItsParts(t *testing.T) {
+	// https://twitter.com/TwitterDev/status/1050118621198921728
+	s, err := Parse(1050118621198921728)
+	if err != nil {
+		t.Fatalf("Parse failed: %s", err.Error())
+	}
+
+	expected := time.Date(2018, time.October, 10, 20, 19, 24, 211*int(time.Millisecond), time.UTC)
+	if !s.Time.Equal(expected) {
+		t.Fatalf("time: %s != expected: %s", s.Time, expected)
+	}
+
+	if s.Datacenter != 10 || s.Worker != 27 || s.Sequence != 0 {
+		t.Fatalf("datacenter: %d, worker: %d, sequence: %d != expected: 10, 27, 0", s.Datacenter, s.Worker, s.Sequence)
+	}
+}
+
+func TestParseRejectsSequentialIDs(t *testing.T) {
+	_, err := Parse(20)
+	if err == nil {
+		t.Fatalf("expected an error for a sequential id")
+	}
+
+	if !Time(20).IsZero() {
+		t.Fatalf("time: %s != expected: zero time", Time(20))
+	}
+}
+
+func TestMinAndMaxIDBoundEveryIDCreatedAtATime(t *testing.T) {
+	id := tweetgo.ID(1050118621198921728)
+	created := Time(id)
+
+	if min := MinID(created); min > id || Time(min) != created {
+		t.Fatalf("min id: %d is not the smallest id created at %s", min, created)
+	}
+
+	if max := MaxID(created); max < id || Time(max) != created {
+		t.Fatalf("max id: %d is not the largest id created at %s", max, created)
+	}
+
+	if MaxID(created)+1 != MinID(created.Add(time.Millisecond)) {
+		t.Fatalf("ids created one millisecond apart should be contiguous")
+	}
+
+	if min := MinID(time.Date(2006, time.March, 21, 0, 0, 0, 0, time.UTC)); min != 0 {
+		t.Fatalf("min id: %d != expected: 0", min)
+	}
+}
+
+func TestCompareOrdersIDsByValue(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{"20", "1050118621198921728", -1},
+		{"1050118621198921728", "1050118621198921729", -1},
+		{"1050118621198921729", "1050118621198921728", 1},
+		{"1050118621198921728", "1050118621198921728", 0},
+		{"9", "10", -1},
+		{"010", "10", 0},
+	}
+
+	for _, test := range tests {
+		if actual := Compare(test.a, test.b); actual != test.expected {
+			t.Errorf("Compare(%s, %s): %d != expected: %d", test.a, test.b, actual, test.expected)
+		}
+	}
+}
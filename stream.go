@@ -0,0 +1,781 @@
+package tweetgo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultStreamIdleTimeout is how long a stream may go without receiving any data, including keep-alives, before it
+// is considered stalled. Twitter sends a keep-alive every 30 seconds and recommends waiting 90 seconds.
+const DefaultStreamIdleTimeout = 90 * time.Second
+
+// ErrStreamStalled is returned when a stream didn't receive any data within its IdleTimeout
+var ErrStreamStalled = errors.New("stream stalled: no data received within the idle timeout")
+
+// FilterStream reads the messages from a statuses/filter or statuses/sample response and decodes them into
+// StatusesFilterOutput
+// https://developer.twitter.com/en/docs/tweets/filter-realtime/guides/streaming-message-types
+type FilterStream struct {
+	// Reconnect will make the stream reconnect when the connection drops, following twitter's backoff strategy. It
+	// only has an effect on streams created by the client, such as StatusesFilterStream and
+	// StatusesSampleStream.
+	Reconnect bool
+	// OnReconnect is called before every reconnection attempt
+	OnReconnect func(ReconnectEvent)
+	// IdleTimeout is how long a read, or a reconnection attempt, may wait for data before the connection is closed as
+	// stalled, which will trigger a reconnect if Reconnect is set. Zero disables the timeout.
+	IdleTimeout time.Duration
+	// OnStallWarning is called when twitter warns that the stream is falling behind, before it disconnects. Warnings
+	// are only sent when the stream was opened with stall_warnings=true.
+	OnStallWarning func(StreamWarning)
+	// Recorder will record every message received, before it is decoded, when set
+	Recorder *StreamRecorder
+	// Backfill will search for the tweets published while the stream was reconnecting and deliver them with
+	// Backfilled set, skipping any that are also delivered by the stream. It only has an effect on streams created by
+	// StatusesFilterStream with Track set.
+	Backfill bool
+	// OnBackfillError is called when searching for missed tweets fails, the stream carries on without them. It is
+	// also called with ErrBackfillIncomplete when the backfill gave up after too many searches.
+	OnBackfillError func(error)
+	// Decoder decodes every message read from the stream, encoding/json is used when it is nil
+	Decoder StreamDecoder
+	// Projection will only decode the selected fields of every tweet when set
+	Projection *Projection
+
+	connect     func(ctx context.Context) (*http.Response, error)
+	backoff     streamBackoff
+	reconnected bool
+
+	search       func(sinceID ID) ([]StatusesFilterOutput, error)
+	seen         *idWindow
+	lastID       ID
+	lastReceived time.Time
+
+	body      io.ReadCloser
+	reader    messageSource
+	delimited bool
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	// ctx is cancelled by Stop to abort a connection attempt
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu  sync.Mutex
+	err error
+}
+
+// ReconnectEvent describes a reconnection attempt made by a stream
+type ReconnectEvent struct {
+	// Attempt is the number of attempts made since the connection was lost, starting at 1
+	Attempt int
+	// Err is the error that caused the reconnection
+	Err error
+	// Backoff is how long the stream will wait before connecting again
+	Backoff time.Duration
+}
+
+// NewFilterStream will create a FilterStream from the response returned by StatusesFilterPostRaw or
+// StatusesSampleGetRaw. Set delimited to true if the request was made with delimited=length. Streams created this way
+// can't reconnect.
+func NewFilterStream(res *http.Response, delimited bool) *FilterStream {
+	fs := newFilterStream(delimited)
+	fs.setBody(res.Body)
+
+	return fs
+}
+
+func newFilterStream(delimited bool) *FilterStream {
+	fs := &FilterStream{
+		IdleTimeout: DefaultStreamIdleTimeout,
+		delimited:   delimited,
+		stop:        make(chan struct{}),
+	}
+	fs.ctx, fs.cancel = context.WithCancel(context.Background())
+
+	return fs
+}
+
+func newReconnectingFilterStream(connect func(ctx context.Context) (*http.Response, error), delimited bool) (*FilterStream, error) {
+	fs := newFilterStream(delimited)
+	fs.connect = connect
+
+	res, err := fs.dial()
+	if err != nil {
+		fs.cancel()
+		return nil, err
+	}
+	fs.setBody(res.Body)
+
+	return fs, nil
+}
+
+// dial will make a connection for the stream. Like reads, connecting fails with ErrStreamStalled when the response
+// doesn't arrive within the IdleTimeout, and it is aborted when the stream is stopped.
+func (s *FilterStream) dial() (*http.Response, error) {
+	ctx, cancel := context.WithCancel(s.ctx)
+
+	var timer *time.Timer
+	if s.IdleTimeout > 0 {
+		timer = time.AfterFunc(s.IdleTimeout, cancel)
+	}
+
+	res, err := s.connect(ctx)
+	if timer != nil && !timer.Stop() {
+		if err == nil {
+			res.Body.Close()
+		}
+		cancel()
+
+		return nil, ErrStreamStalled
+	}
+
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	res.Body = &cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+
+	return res, nil
+}
+
+// cancelOnClose releases the context of a connection once its body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+
+	return err
+}
+
+// Run will read the stream and call handler for every tweet until the stream ends or Stop is called. Messages which
+// are not tweets are skipped, use RunDemux to handle them. Run returns nil when the stream was stopped, otherwise it
+// returns the error that ended the stream.
+func (s *FilterStream) Run(handler func(StatusesFilterOutput)) error {
+	return s.RunDemux(StreamDemux{Tweet: handler})
+}
+
+// RunDemux will read the stream and dispatch every message to the matching handler in d until the stream ends or Stop
+// is called. RunDemux returns nil when the stream was stopped, otherwise it returns the error that ended the stream.
+func (s *FilterStream) RunDemux(d StreamDemux) error {
+	defer s.Stop()
+
+	if d.Decoder == nil {
+		d.Decoder = s.Decoder
+	}
+
+	if d.Projection == nil {
+		d.Projection = s.Projection
+	}
+
+	if d.Tweet != nil || s.Backfill {
+		tweet := d.Tweet
+		d.Tweet = func(output StatusesFilterOutput) {
+			if s.deliverable(output) && tweet != nil {
+				tweet(output)
+			}
+		}
+	}
+
+	if s.OnStallWarning != nil {
+		warning := d.Warning
+		d.Warning = func(w StreamWarning) {
+			s.OnStallWarning(w)
+			if warning != nil {
+				warning(w)
+			}
+		}
+	}
+
+	for {
+		msg, err := s.next()
+		if s.stopped() {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if s.reconnected {
+			s.reconnected = false
+			s.backfill(d.Tweet)
+		}
+
+		if s.Recorder != nil {
+			err = s.Recorder.Record(msg, time.Now())
+			if err != nil {
+				return err
+			}
+		}
+
+		err = d.Dispatch(msg)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// LastTweet will return the id of the newest tweet received and when it arrived
+func (s *FilterStream) LastTweet() (ID, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.lastID, s.lastReceived
+}
+
+// deliverable will remember output as the last tweet seen and report whether it should be delivered, which it
+// shouldn't be if it is a duplicate of a backfilled tweet
+func (s *FilterStream) deliverable(output StatusesFilterOutput) bool {
+	s.mu.Lock()
+	if output.ID > s.lastID {
+		s.lastID = output.ID
+		s.lastReceived = time.Now()
+	}
+	s.mu.Unlock()
+
+	if !s.Backfill {
+		return true
+	}
+
+	if s.seen == nil {
+		s.seen = newIDWindow(DefaultDedupeWindow)
+	}
+
+	return s.seen.add(output.ID)
+}
+
+// backfill will deliver the tweets published since the last tweet was received
+func (s *FilterStream) backfill(tweet func(StatusesFilterOutput)) {
+	sinceID, _ := s.LastTweet()
+	if !s.Backfill || s.search == nil || sinceID == 0 {
+		return
+	}
+
+	// an incomplete backfill still returns the tweets it found
+	missed, err := s.search(sinceID)
+	if err != nil && s.OnBackfillError != nil {
+		s.OnBackfillError(err)
+	}
+
+	for _, output := range missed {
+		if s.stopped() {
+			return
+		}
+
+		output.Backfilled = true
+		tweet(output)
+	}
+}
+
+// Messages will start reading the stream in the background and return a channel of tweets. The channel is closed when
+// the stream ends, after which Err will return the reason the stream ended.
+func (s *FilterStream) Messages() <-chan StatusesFilterOutput {
+	messages := make(chan StatusesFilterOutput)
+
+	go func() {
+		defer close(messages)
+
+		err := s.Run(func(output StatusesFilterOutput) {
+			select {
+			case messages <- output:
+			case <-s.stop:
+			}
+		})
+
+		s.mu.Lock()
+		s.err = err
+		s.mu.Unlock()
+	}()
+
+	return messages
+}
+
+// Err will return the error that ended a stream started with Messages, or nil if the stream was stopped
+func (s *FilterStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+// Stop will close the connection and end the stream. It is safe to call Stop more than once.
+func (s *FilterStream) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		if s.cancel != nil {
+			s.cancel()
+		}
+
+		s.mu.Lock()
+		s.body.Close()
+		s.mu.Unlock()
+	})
+}
+
+func (s *FilterStream) stopped() bool {
+	select {
+	case <-s.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// setBody will start reading from body, enforcing the idle timeout on every read
+func (s *FilterStream) setBody(body io.ReadCloser) {
+	idle := &idleReader{
+		stream: s,
+		body:   body,
+	}
+
+	s.body = idle
+	s.reader = newMessageReader(idle, s.delimited)
+}
+
+// next will return the next message, reconnecting first if the connection was lost and reconnection is enabled
+func (s *FilterStream) next() ([]byte, error) {
+	for {
+		msg, err := s.reader.next()
+		if err == nil || s.stopped() || !s.Reconnect || s.connect == nil {
+			return msg, err
+		}
+
+		err = s.reconnect(err)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (s *FilterStream) reconnect(cause error) error {
+	s.mu.Lock()
+	s.body.Close()
+	s.mu.Unlock()
+
+	for attempt := 1; ; attempt++ {
+		var httpErr *HTTPError
+		if errors.As(cause, &httpErr) && !retryableStatus(httpErr.StatusCode) {
+			return cause
+		}
+
+		wait := s.backoff.next(cause)
+		if s.OnReconnect != nil {
+			s.OnReconnect(ReconnectEvent{
+				Attempt: attempt,
+				Err:     cause,
+				Backoff: wait,
+			})
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-s.stop:
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+
+		res, err := s.dial()
+		if err != nil {
+			cause = err
+			continue
+		}
+
+		s.mu.Lock()
+		if s.stopped() {
+			res.Body.Close()
+		} else {
+			s.setBody(res.Body)
+		}
+		s.mu.Unlock()
+
+		s.backoff.reset()
+		s.reconnected = true
+
+		return nil
+	}
+}
+
+// idleReader closes the body when a single read waits longer than the streams IdleTimeout, which unblocks the read
+// with ErrStreamStalled
+type idleReader struct {
+	stream  *FilterStream
+	body    io.ReadCloser
+	timer   *time.Timer
+	stalled int32
+}
+
+func (r *idleReader) Read(p []byte) (int, error) {
+	timeout := r.stream.IdleTimeout
+	if timeout > 0 {
+		if r.timer == nil {
+			r.timer = time.AfterFunc(timeout, r.stall)
+		} else {
+			r.timer.Reset(timeout)
+		}
+	}
+
+	n, err := r.body.Read(p)
+
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+
+	if err != nil && atomic.LoadInt32(&r.stalled) == 1 {
+		return n, ErrStreamStalled
+	}
+
+	return n, err
+}
+
+func (r *idleReader) stall() {
+	atomic.StoreInt32(&r.stalled, 1)
+	r.body.Close()
+}
+
+func (r *idleReader) Close() error {
+	return r.body.Close()
+}
+
+// retryableStatus reports whether reconnecting after the status could ever succeed. Authentication failures, unknown
+// endpoints and rejected parameters will fail the same way every time.
+func retryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusUnauthorized,
+		http.StatusForbidden,
+		http.StatusNotFound,
+		http.StatusNotAcceptable,
+		http.StatusRequestEntityTooLarge,
+		http.StatusRequestedRangeNotSatisfiable:
+		return false
+	}
+
+	return true
+}
+
+// streamBackoff implements twitter's reconnection strategy
+// https://developer.twitter.com/en/docs/tweets/filter-realtime/guides/connecting
+type streamBackoff struct {
+	network time.Duration
+	http    time.Duration
+	limited time.Duration
+}
+
+const (
+	networkBackoffStep = 250 * time.Millisecond
+	networkBackoffMax  = 16 * time.Second
+	httpBackoffMin     = 5 * time.Second
+	httpBackoffMax     = 320 * time.Second
+	limitedBackoffMin  = time.Minute
+	limitedBackoffMax  = 16 * time.Minute
+)
+
+// next will return how long to wait before reconnecting after err. Network errors back off linearly, HTTP errors back
+// off exponentially and rate limiting (420 and 429) backs off exponentially starting at one minute.
+func (b *streamBackoff) next(err error) time.Duration {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		b.network += networkBackoffStep
+		if b.network > networkBackoffMax {
+			b.network = networkBackoffMax
+		}
+
+		return b.network
+	}
+
+	if httpErr.StatusCode == 420 || httpErr.StatusCode == http.StatusTooManyRequests {
+		if b.limited == 0 {
+			b.limited = limitedBackoffMin
+		} else if b.limited < limitedBackoffMax {
+			b.limited *= 2
+		}
+
+		return b.limited
+	}
+
+	if b.http == 0 {
+		b.http = httpBackoffMin
+	} else if b.http < httpBackoffMax {
+		b.http *= 2
+	}
+
+	return b.http
+}
+
+func (b *streamBackoff) reset() {
+	*b = streamBackoff{}
+}
+
+// StreamDemux routes every message read from a stream to the handler for its type. Messages without a handler are
+// skipped.
+type StreamDemux struct {
+	Tweet          func(StatusesFilterOutput)
+	Delete         func(StreamDelete)
+	ScrubGeo       func(StreamScrubGeo)
+	Limit          func(StreamLimit)
+	StatusWithheld func(StreamStatusWithheld)
+	UserWithheld   func(StreamUserWithheld)
+	Disconnect     func(StreamDisconnect)
+	Warning        func(StreamWarning)
+	// Unknown is called with any message which isn't one of the types above
+	Unknown func(json.RawMessage)
+	// Decoder decodes the messages, encoding/json is used when it is nil
+	Decoder StreamDecoder
+	// Projection will only decode the selected fields of tweets when set
+	Projection *Projection
+}
+
+// StreamDecoder decodes the JSON of stream messages. It can be used to swap encoding/json for a faster library, which
+// must support json.RawMessage and the UnmarshalJSON methods of the outputs.
+type StreamDecoder interface {
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// StreamDecoderFunc adapts an unmarshal function, like jsoniter.ConfigCompatibleWithStandardLibrary.Unmarshal, to a
+// StreamDecoder
+type StreamDecoderFunc func(data []byte, v interface{}) error
+
+// Unmarshal will call f
+func (f StreamDecoderFunc) Unmarshal(data []byte, v interface{}) error {
+	return f(data, v)
+}
+
+// tweetPrefix is how every tweet delivered by the streaming API starts, which lets tweets skip decoding the envelope
+var tweetPrefix = []byte(`{"created_at"`)
+
+// streamEnvelope is used to find out which type of message was received. Only the top level keys are inspected.
+type streamEnvelope struct {
+	ID             json.RawMessage `json:"id"`
+	Delete         json.RawMessage `json:"delete"`
+	ScrubGeo       json.RawMessage `json:"scrub_geo"`
+	Limit          json.RawMessage `json:"limit"`
+	StatusWithheld json.RawMessage `json:"status_withheld"`
+	UserWithheld   json.RawMessage `json:"user_withheld"`
+	Disconnect     json.RawMessage `json:"disconnect"`
+	Warning        json.RawMessage `json:"warning"`
+}
+
+// present reports whether a key of the envelope was set to something other than null
+func present(raw json.RawMessage) bool {
+	return raw != nil && string(raw) != "null"
+}
+
+// Dispatch will decode a single stream message and call the matching handler
+func (d StreamDemux) Dispatch(msg []byte) error {
+	unmarshal := json.Unmarshal
+	if d.Decoder != nil {
+		unmarshal = d.Decoder.Unmarshal
+	}
+
+	var err error
+	env := streamEnvelope{}
+	tweet := bytes.HasPrefix(bytes.TrimLeft(msg, " \t\r\n"), tweetPrefix)
+	if !tweet {
+		err = unmarshal(msg, &env)
+		if err != nil {
+			return err
+		}
+
+		tweet = present(env.ID)
+	}
+
+	switch {
+	case tweet:
+		if d.Tweet == nil {
+			return nil
+		}
+
+		output := StatusesFilterOutput{}
+		if d.Projection != nil {
+			err = d.Projection.decode(msg, &output, unmarshal)
+		} else {
+			err = unmarshal(msg, &output)
+		}
+		if err != nil {
+			return err
+		}
+
+		d.Tweet(output)
+	case present(env.Delete):
+		if d.Delete == nil {
+			return nil
+		}
+
+		output := StreamDelete{}
+		err = unmarshal(env.Delete, &output)
+		if err != nil {
+			return err
+		}
+
+		d.Delete(output)
+	case present(env.ScrubGeo):
+		if d.ScrubGeo == nil {
+			return nil
+		}
+
+		output := StreamScrubGeo{}
+		err = unmarshal(env.ScrubGeo, &output)
+		if err != nil {
+			return err
+		}
+
+		d.ScrubGeo(output)
+	case present(env.Limit):
+		if d.Limit == nil {
+			return nil
+		}
+
+		output := StreamLimit{}
+		err = unmarshal(env.Limit, &output)
+		if err != nil {
+			return err
+		}
+
+		d.Limit(output)
+	case present(env.StatusWithheld):
+		if d.StatusWithheld == nil {
+			return nil
+		}
+
+		output := StreamStatusWithheld{}
+		err = unmarshal(env.StatusWithheld, &output)
+		if err != nil {
+			return err
+		}
+
+		d.StatusWithheld(output)
+	case present(env.UserWithheld):
+		if d.UserWithheld == nil {
+			return nil
+		}
+
+		output := StreamUserWithheld{}
+		err = unmarshal(env.UserWithheld, &output)
+		if err != nil {
+			return err
+		}
+
+		d.UserWithheld(output)
+	case present(env.Disconnect):
+		if d.Disconnect == nil {
+			return nil
+		}
+
+		output := StreamDisconnect{}
+		err = unmarshal(env.Disconnect, &output)
+		if err != nil {
+			return err
+		}
+
+		d.Disconnect(output)
+	case present(env.Warning):
+		if d.Warning == nil {
+			return nil
+		}
+
+		output := StreamWarning{}
+		err = unmarshal(env.Warning, &output)
+		if err != nil {
+			return err
+		}
+
+		d.Warning(output)
+	default:
+		if d.Unknown != nil {
+			// msg may be reused by the stream once Dispatch returns
+			d.Unknown(append(json.RawMessage(nil), msg...))
+		}
+	}
+
+	return nil
+}
+
+// messageSource is anything that messages can be read from one at a time. A message is only valid until next is
+// called again, because sources may reuse its memory.
+type messageSource interface {
+	next() ([]byte, error)
+}
+
+// messageReader splits a stream body into individual messages, skipping the blank keep-alive lines twitter sends.
+// Every message is read into the same buffer, so reading a message doesn't allocate once the buffer has grown.
+type messageReader struct {
+	reader    *bufio.Reader
+	delimited bool
+	buf       []byte
+}
+
+func newMessageReader(r io.Reader, delimited bool) *messageReader {
+	return &messageReader{
+		reader:    bufio.NewReader(r),
+		delimited: delimited,
+	}
+}
+
+func (m *messageReader) next() ([]byte, error) {
+	for {
+		line, err := m.readLine()
+		line = bytes.TrimSpace(line)
+		if err != nil && len(line) == 0 {
+			return nil, err
+		}
+
+		// keep-alive
+		if len(line) == 0 {
+			continue
+		}
+
+		if !m.delimited {
+			return line, nil
+		}
+
+		// with delimited=length every message is preceded by a line containing its length in bytes
+		length, err := strconv.Atoi(string(line))
+		if err != nil || length < 0 {
+			return nil, errors.New("invalid message length: " + string(line))
+		}
+
+		if cap(m.buf) < length {
+			m.buf = make([]byte, length)
+		}
+
+		msg := m.buf[:length]
+		_, err = io.ReadFull(m.reader, msg)
+		if err != nil {
+			return nil, err
+		}
+
+		msg = bytes.TrimSpace(msg)
+		if len(msg) == 0 {
+			continue
+		}
+
+		return msg, nil
+	}
+}
+
+// readLine will read up to and including the next newline into buf
+func (m *messageReader) readLine() ([]byte, error) {
+	m.buf = m.buf[:0]
+	for {
+		chunk, err := m.reader.ReadSlice('\n')
+		m.buf = append(m.buf, chunk...)
+		if err != bufio.ErrBufferFull {
+			return m.buf, err
+		}
+	}
+}
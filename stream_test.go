@@ -0,0 +1,465 @@
+package tweetgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestFilterStreamSkipsKeepAlives(t *testing.T) {
+	body := "\r\n{\"id\":1,\"text\":\"one\"}\r\n\r\n\r\n{\"id\":2,\"text\":\"two\"}\r\n"
+	fs := NewFilterStream(newTestResponse(body), false)
+
+	var ids []ID
+	err := fs.Run(func(output StatusesFilterOutput) {
+		ids = append(ids, output.ID)
+	})
+	if err == nil {
+		t.Fatalf("expected the end of the body to end the stream")
+	}
+
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Fatalf("ids: %v != expected: [1 2]", ids)
+	}
+}
+
+func TestFilterStreamReadsDelimitedMessages(t *testing.T) {
+	first := "{\"id\":1,\"text\":\"multi\\nline\"}\r\n"
+	second := "{\"id\":2,\"text\":\"two\"}\r\n"
+	body := strconv.Itoa(len(first)) + "\r\n" + first + "\r\n" + strconv.Itoa(len(second)) + "\r\n" + second
+
+	fs := NewFilterStream(newTestResponse(body), true)
+
+	var texts []string
+	for output := range fs.Messages() {
+		texts = append(texts, output.Text)
+	}
+
+	if len(texts) != 2 || texts[0] != "multi\nline" || texts[1] != "two" {
+		t.Fatalf("texts: %q != expected: [\"multi\\nline\" \"two\"]", texts)
+	}
+
+	if fs.Err() == nil {
+		t.Fatalf("expected the end of the body to be reported by Err")
+	}
+}
+
+func TestFilterStreamStopEndsRunWithoutError(t *testing.T) {
+	fs := NewFilterStream(newTestResponse("{\"id\":1}\r\n{\"id\":2}\r\n"), false)
+
+	count := 0
+	err := fs.Run(func(output StatusesFilterOutput) {
+		count++
+		fs.Stop()
+	})
+	if err != nil {
+		t.Fatalf("expected nil error after Stop, got: %s", err.Error())
+	}
+
+	if count != 1 {
+		t.Fatalf("count: %d != expected: 1", count)
+	}
+}
+
+func TestStreamBackoffFollowsTwitterStrategy(t *testing.T) {
+	b := streamBackoff{}
+
+	networkErr := errors.New("connection reset")
+	for i, expected := range []time.Duration{250 * time.Millisecond, 500 * time.Millisecond, 750 * time.Millisecond} {
+		if wait := b.next(networkErr); wait != expected {
+			t.Fatalf("network attempt %d: %s != expected: %s", i+1, wait, expected)
+		}
+	}
+
+	httpErr := &HTTPError{StatusCode: http.StatusServiceUnavailable}
+	for i, expected := range []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second} {
+		if wait := b.next(httpErr); wait != expected {
+			t.Fatalf("http attempt %d: %s != expected: %s", i+1, wait, expected)
+		}
+	}
+
+	limitedErr := &HTTPError{StatusCode: 420}
+	for i, expected := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute} {
+		if wait := b.next(limitedErr); wait != expected {
+			t.Fatalf("rate limited attempt %d: %s != expected: %s", i+1, wait, expected)
+		}
+	}
+
+	b.reset()
+	if wait := b.next(networkErr); wait != networkBackoffStep {
+		t.Fatalf("after reset: %s != expected: %s", wait, networkBackoffStep)
+	}
+}
+
+func TestFilterStreamReconnectsAfterConnectionDrops(t *testing.T) {
+	bodies := []string{"{\"id\":1}\r\n", "{\"id\":2}\r\n"}
+	connect := func(ctx context.Context) (*http.Response, error) {
+		if len(bodies) == 0 {
+			return nil, &HTTPError{StatusCode: http.StatusUnauthorized, Status: "401 Unauthorized"}
+		}
+
+		res := newTestResponse(bodies[0])
+		bodies = bodies[1:]
+
+		return res, nil
+	}
+
+	fs, err := newReconnectingFilterStream(connect, false)
+	if err != nil {
+		t.Fatalf("connect failed: %s", err.Error())
+	}
+	fs.Reconnect = true
+
+	var events []ReconnectEvent
+	fs.OnReconnect = func(event ReconnectEvent) {
+		events = append(events, event)
+	}
+
+	var ids []ID
+	err = fs.Run(func(output StatusesFilterOutput) {
+		ids = append(ids, output.ID)
+	})
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected the stream to give up on 401, got: %v", err)
+	}
+
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Fatalf("ids: %v != expected: [1 2]", ids)
+	}
+
+	if len(events) != 2 || events[0].Err != io.EOF || events[0].Backoff != networkBackoffStep {
+		t.Fatalf("unexpected reconnect events: %+v", events)
+	}
+}
+
+// stalledConnect connects once and then hangs on every later attempt until the request is cancelled
+func stalledConnect(attempts chan<- struct{}) func(ctx context.Context) (*http.Response, error) {
+	connected := false
+
+	return func(ctx context.Context) (*http.Response, error) {
+		if !connected {
+			connected = true
+			return newTestResponse("{\"id\":1}\r\n"), nil
+		}
+
+		attempts <- struct{}{}
+		<-ctx.Done()
+
+		return nil, ctx.Err()
+	}
+}
+
+func TestFilterStreamReconnectTimesOutWhenConnectStalls(t *testing.T) {
+	attempts := make(chan struct{}, 10)
+	fs, err := newReconnectingFilterStream(stalledConnect(attempts), false)
+	if err != nil {
+		t.Fatalf("connect failed: %s", err.Error())
+	}
+	fs.Reconnect = true
+	fs.IdleTimeout = 50 * time.Millisecond
+
+	stalled := make(chan error, 10)
+	fs.OnReconnect = func(event ReconnectEvent) {
+		stalled <- event.Err
+	}
+
+	go fs.Run(func(StatusesFilterOutput) {})
+	defer fs.Stop()
+
+	<-stalled
+	select {
+	case err := <-stalled:
+		if err != ErrStreamStalled {
+			t.Fatalf("reconnect error: %v != expected: %v", err, ErrStreamStalled)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("the stalled connection attempt never timed out")
+	}
+}
+
+func TestFilterStreamStopCancelsAStalledConnect(t *testing.T) {
+	attempts := make(chan struct{}, 10)
+	fs, err := newReconnectingFilterStream(stalledConnect(attempts), false)
+	if err != nil {
+		t.Fatalf("connect failed: %s", err.Error())
+	}
+	fs.Reconnect = true
+	fs.IdleTimeout = 0
+
+	done := make(chan error)
+	go func() {
+		done <- fs.Run(func(StatusesFilterOutput) {})
+	}()
+
+	<-attempts
+	fs.Stop()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("expected nil after Stop, got: %s", err.Error())
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Stop didn't interrupt the connection attempt")
+	}
+}
+
+func TestStreamDemuxRoutesMessagesByType(t *testing.T) {
+	body := strings.Join([]string{
+		`{"id":1,"text":"a tweet"}`,
+		`{"delete":{"status":{"id":2,"id_str":"2","user_id":3,"user_id_str":"3"},"timestamp_ms":"1"}}`,
+		`{"scrub_geo":{"user_id":3,"user_id_str":"3","up_to_status_id":4,"up_to_status_id_str":"4"}}`,
+		`{"limit":{"track":1234,"timestamp_ms":"1"}}`,
+		`{"status_withheld":{"id":5,"user_id":3,"withheld_in_countries":["DE"]}}`,
+		`{"user_withheld":{"id":3,"withheld_in_countries":["DE","AR"]}}`,
+		`{"warning":{"code":"FALLING_BEHIND","message":"behind","percent_full":60}}`,
+		`{"friends":[1,2,3]}`,
+		`{"id":null,"foo":1}`,
+		`{"disconnect":{"code":7,"stream_name":"test","reason":"admin logout"}}`,
+	}, "\r\n") + "\r\n"
+
+	var seen []string
+	d := StreamDemux{
+		Tweet:          func(o StatusesFilterOutput) { seen = append(seen, "tweet:"+o.Text) },
+		Delete:         func(o StreamDelete) { seen = append(seen, "delete:"+o.Status.ID.String()) },
+		ScrubGeo:       func(o StreamScrubGeo) { seen = append(seen, "scrub_geo:"+o.UpToStatusID.String()) },
+		Limit:          func(o StreamLimit) { seen = append(seen, "limit:"+strconv.Itoa(o.Track)) },
+		StatusWithheld: func(o StreamStatusWithheld) { seen = append(seen, "status_withheld:"+o.WithheldInCountries[0]) },
+		UserWithheld:   func(o StreamUserWithheld) { seen = append(seen, "user_withheld:"+o.WithheldInCountries[1]) },
+		Warning:        func(o StreamWarning) { seen = append(seen, "warning:"+o.Code) },
+		Unknown:        func(o json.RawMessage) { seen = append(seen, "unknown:"+string(o)) },
+		Disconnect:     func(o StreamDisconnect) { seen = append(seen, "disconnect:"+o.Reason) },
+	}
+
+	fs := NewFilterStream(newTestResponse(body), false)
+	err := fs.RunDemux(d)
+	if err != io.EOF {
+		t.Fatalf("expected io.EOF at the end of the body, got: %v", err)
+	}
+
+	expected := []string{
+		"tweet:a tweet",
+		"delete:2",
+		"scrub_geo:4",
+		"limit:1234",
+		"status_withheld:DE",
+		"user_withheld:AR",
+		"warning:FALLING_BEHIND",
+		`unknown:{"friends":[1,2,3]}`,
+		`unknown:{"id":null,"foo":1}`,
+		"disconnect:admin logout",
+	}
+
+	if !reflect.DeepEqual(expected, seen) {
+		t.Fatalf("seen: %q != expected: %q", seen, expected)
+	}
+}
+
+func TestFilterStreamRunSkipsNonTweetMessages(t *testing.T) {
+	body := "{\"limit\":{\"track\":10}}\r\n{\"id\":1,\"text\":\"tweet\"}\r\n"
+	fs := NewFilterStream(newTestResponse(body), false)
+
+	var texts []string
+	fs.Run(func(output StatusesFilterOutput) {
+		texts = append(texts, output.Text)
+	})
+
+	if len(texts) != 1 || texts[0] != "tweet" {
+		t.Fatalf("texts: %q != expected: [\"tweet\"]", texts)
+	}
+}
+
+func TestFilterStreamReportsStalledConnections(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	go pw.Write([]byte("{\"id\":1}\r\n\r\n"))
+
+	fs := NewFilterStream(&http.Response{StatusCode: http.StatusOK, Body: pr}, false)
+	fs.IdleTimeout = 20 * time.Millisecond
+
+	count := 0
+	err := fs.Run(func(output StatusesFilterOutput) {
+		count++
+	})
+	if err != ErrStreamStalled {
+		t.Fatalf("expected ErrStreamStalled, got: %v", err)
+	}
+
+	if count != 1 {
+		t.Fatalf("count: %d != expected: 1", count)
+	}
+}
+
+func TestFilterStreamCallsOnStallWarning(t *testing.T) {
+	body := "{\"warning\":{\"code\":\"FALLING_BEHIND\",\"percent_full\":80}}\r\n"
+	fs := NewFilterStream(newTestResponse(body), false)
+
+	var warnings []StreamWarning
+	fs.OnStallWarning = func(w StreamWarning) {
+		warnings = append(warnings, w)
+	}
+	fs.Run(func(output StatusesFilterOutput) {})
+
+	if len(warnings) != 1 || warnings[0].PercentFull != 80 {
+		t.Fatalf("unexpected warnings: %+v", warnings)
+	}
+}
+
+func TestDemuxUsesTheStreamDecoder(t *testing.T) {
+	calls := 0
+	decoder := StreamDecoderFunc(func(data []byte, v interface{}) error {
+		calls++
+		return json.Unmarshal(data, v)
+	})
+
+	var texts []string
+	d := StreamDemux{
+		Tweet:   func(o StatusesFilterOutput) { texts = append(texts, o.Text) },
+		Limit:   func(o StreamLimit) { texts = append(texts, "limit") },
+		Decoder: decoder,
+	}
+
+	for _, msg := range []string{`{"created_at":"Wed Oct 10 20:19:24 +0000 2018","id":1,"text":"fast"}`, `{"id":2,"text":"slow"}`, `{"limit":{"track":1}}`} {
+		err := d.Dispatch([]byte(msg))
+		if err != nil {
+			t.Fatalf("Dispatch failed: %s", err.Error())
+		}
+	}
+
+	if !reflect.DeepEqual(texts, []string{"fast", "slow", "limit"}) {
+		t.Fatalf("texts: %v != expected: [fast slow limit]", texts)
+	}
+
+	// tweets starting with created_at are decoded once, everything else also decodes the envelope
+	if calls != 5 {
+		t.Fatalf("decoder calls: %d != expected: 5", calls)
+	}
+}
+
+func benchmarkStreamTweet(b *testing.B) []byte {
+	raw, err := ioutil.ReadFile("testdata/tweet_withheld.json")
+	if err != nil {
+		b.Fatalf("could not read fixture: %s", err.Error())
+	}
+
+	compact := &bytes.Buffer{}
+	err = json.Compact(compact, raw)
+	if err != nil {
+		b.Fatalf("could not compact fixture: %s", err.Error())
+	}
+
+	return compact.Bytes()
+}
+
+func BenchmarkDispatchTweet(b *testing.B) {
+	msg := benchmarkStreamTweet(b)
+	d := StreamDemux{Tweet: func(StatusesFilterOutput) {}}
+	b.SetBytes(int64(len(msg)))
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		err := d.Dispatch(msg)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkDispatchTweetWithEnvelope decodes a tweet which doesn't start with created_at, so the message type has to
+// be found by decoding the envelope first like every message did before
+func BenchmarkDispatchTweetWithEnvelope(b *testing.B) {
+	msg := append([]byte(`{"envelope":true,`), benchmarkStreamTweet(b)[1:]...)
+	d := StreamDemux{Tweet: func(StatusesFilterOutput) {}}
+	b.SetBytes(int64(len(msg)))
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		err := d.Dispatch(msg)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// repeatReader reads the same bytes over and over without ever ending
+type repeatReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *repeatReader) Read(p []byte) (int, error) {
+	n := copy(p, r.data[r.pos:])
+	r.pos = (r.pos + n) % len(r.data)
+
+	return n, nil
+}
+
+func BenchmarkMessageReader(b *testing.B) {
+	msg := benchmarkStreamTweet(b)
+	reader := newMessageReader(&repeatReader{data: append(append([]byte{}, msg...), '\r', '\n')}, false)
+	b.SetBytes(int64(len(msg)))
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, err := reader.next()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestStatusesSampleStreamReadsTheSampleEndpoint(t *testing.T) {
+	fake := newFakeClient(http.StatusOK, "{\"id\":1,\"text\":\"one\"}\r\n\r\n{\"id\":2,\"text\":\"two\"}\r\n")
+	tc := NewClient("key", "secret")
+	tc.HTTPClient = fake
+
+	fs, err := tc.StatusesSampleStream(StatusesSampleInput{StallWarnings: String("true")})
+	if err != nil {
+		t.Fatalf("StatusesSampleStream failed: %s", err.Error())
+	}
+
+	var texts []string
+	err = fs.Run(func(output StatusesFilterOutput) {
+		texts = append(texts, output.Text)
+	})
+	if err != io.EOF {
+		t.Fatalf("expected io.EOF at the end of the body, got: %v", err)
+	}
+
+	if !reflect.DeepEqual(texts, []string{"one", "two"}) {
+		t.Fatalf("texts: %q != expected: [one two]", texts)
+	}
+
+	requests := fake.sent()
+	if len(requests) != 1 {
+		t.Fatalf("requests: %d != expected: 1", len(requests))
+	}
+
+	req := requests[0]
+	if req.Method != http.MethodGet || req.URL.Host != "stream.twitter.com" || req.URL.Path != "/1.1/statuses/sample.json" {
+		t.Fatalf("request: %s %s != expected: GET https://stream.twitter.com/1.1/statuses/sample.json", req.Method, req.URL)
+	}
+
+	if req.URL.Query().Get("stall_warnings") != "true" {
+		t.Fatalf("stall_warnings: %q != expected: true", req.URL.Query().Get("stall_warnings"))
+	}
+}
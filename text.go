@@ -0,0 +1,39 @@
+package tweetgo
+
+// TweetModeExtended is the tweet_mode which returns the untruncated text of tweets in FullText rather than Text
+// https://developer.twitter.com/en/docs/tweets/tweet-updates
+const TweetModeExtended = "extended"
+
+// UntruncatedText will return the untruncated text of the tweet, whether it was requested with tweet_mode=extended or
+// delivered by the stream in extended_tweet. Retweets are rebuilt from the full text of the retweeted tweet because the
+// text of the retweet itself is still cut off.
+func (o StatusesFilterOutput) UntruncatedText() string {
+	return untruncatedText(o.Tweet, o.RetweetedStatus)
+}
+
+// UntruncatedText will return the untruncated text of the tweet. Retweets are rebuilt from the full text of the
+// retweeted tweet because the text of the retweet itself is still cut off.
+func (o StatusesUserTimelineOutput) UntruncatedText() string {
+	return untruncatedText(o.Tweet, o.RetweetedStatus)
+}
+
+func untruncatedText(t Tweet, retweeted Tweet) string {
+	if retweeted.ID != 0 {
+		return "RT @" + retweeted.User.ScreenName + ": " + tweetText(retweeted)
+	}
+
+	return tweetText(t)
+}
+
+// tweetText will return the longest text available for a single tweet
+func tweetText(t Tweet) string {
+	if t.ExtendedTweet.FullText != "" {
+		return t.ExtendedTweet.FullText
+	}
+
+	if t.FullText != "" {
+		return t.FullText
+	}
+
+	return t.Text
+}
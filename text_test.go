@@ -0,0 +1,48 @@
+package tweetgo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUntruncatedTextPrefersTheFullText(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected string
+	}{
+		{`{"id":1,"text":"short tweet"}`, "short tweet"},
+		{`{"id":1,"full_text":"rest extended tweet","truncated":false}`, "rest extended tweet"},
+		{`{"id":1,"text":"stream tweet…","truncated":true,"extended_tweet":{"full_text":"stream tweet in full"}}`, "stream tweet in full"},
+		{`{"id":2,"text":"RT @gopher: long…","retweeted_status":{"id":1,"user":{"screen_name":"gopher"},"text":"long…","extended_tweet":{"full_text":"long retweeted text"}}}`, "RT @gopher: long retweeted text"},
+	}
+
+	for _, test := range tests {
+		output := StatusesFilterOutput{}
+		err := json.Unmarshal([]byte(test.raw), &output)
+		if err != nil {
+			t.Fatalf("invalid test tweet: %s", err.Error())
+		}
+
+		if text := output.UntruncatedText(); text != test.expected {
+			t.Errorf("full text: %s != expected: %s", text, test.expected)
+		}
+	}
+}
+
+func TestTimelineUntruncatedTextRebuildsRetweets(t *testing.T) {
+	output := StatusesUserTimelineOutput{}
+	err := json.Unmarshal([]byte(`{"id":2,"full_text":"RT @gopher: cut","retweeted_status":{"id":1,"user":{"screen_name":"gopher"},"full_text":"cut off text restored"}}`), &output)
+	if err != nil {
+		t.Fatalf("invalid test tweet: %s", err.Error())
+	}
+
+	expected := "RT @gopher: cut off text restored"
+	if text := output.UntruncatedText(); text != expected {
+		t.Fatalf("full text: %s != expected: %s", text, expected)
+	}
+
+	// the full_text field itself must still be reachable on the output
+	if output.FullText != "RT @gopher: cut" {
+		t.Fatalf("full_text: %s != expected: RT @gopher: cut", output.FullText)
+	}
+}
@@ -0,0 +1,67 @@
+package tweetgo
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// TimeLayout is the format twitter uses for timestamps in the v1.1 API, such as created_at
+const TimeLayout = time.RubyDate
+
+// Time is a timestamp from the twitter API. It decodes both the v1.1 format, "Mon Jan 02 15:04:05 -0700 2006", and
+// the ISO-8601 format used by v2 and polls, and encodes back to whichever format it was decoded from.
+type Time struct {
+	time.Time
+	layout string
+}
+
+// NewTime will create a Time which is encoded in the v1.1 format
+func NewTime(t time.Time) Time {
+	return Time{Time: t}
+}
+
+// UnmarshalJSON will decode a timestamp in either the v1.1 or ISO-8601 format. Null and empty strings decode to the
+// zero time.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*t = Time{}
+		return nil
+	}
+
+	var value string
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+
+	if value == "" {
+		*t = Time{}
+		return nil
+	}
+
+	for _, layout := range []string{TimeLayout, time.RFC3339Nano} {
+		parsed, err := time.Parse(layout, value)
+		if err == nil {
+			*t = Time{Time: parsed, layout: layout}
+			return nil
+		}
+	}
+
+	return errors.New("unrecognized twitter timestamp: " + value)
+}
+
+// MarshalJSON will encode the timestamp in the format it was decoded from, or the v1.1 format for times created by
+// NewTime. The zero time is encoded as null.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+
+	layout := t.layout
+	if layout == "" {
+		layout = TimeLayout
+	}
+
+	return json.Marshal(t.Format(layout))
+}
@@ -0,0 +1,55 @@
+package tweetgo
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimeDecodesTwitterAndISOFormats(t *testing.T) {
+	var output struct {
+		CreatedAt   Time `json:"created_at"`
+		EndDatetime Time `json:"end_datetime"`
+		Missing     Time `json:"missing"`
+	}
+
+	err := json.Unmarshal([]byte(`{
+		"created_at": "Wed Oct 10 20:19:24 +0000 2018",
+		"end_datetime": "2018-10-11T20:19:24.000Z",
+		"missing": null
+	}`), &output)
+	if err != nil {
+		t.Fatalf("Unmarshal failed: %s", err.Error())
+	}
+
+	expected := time.Date(2018, time.October, 10, 20, 19, 24, 0, time.UTC)
+	if !output.CreatedAt.Equal(expected) {
+		t.Fatalf("created_at: %s != expected: %s", output.CreatedAt, expected)
+	}
+
+	if !output.EndDatetime.Equal(expected.Add(24 * time.Hour)) {
+		t.Fatalf("end_datetime: %s != expected: %s", output.EndDatetime, expected.Add(24*time.Hour))
+	}
+
+	if !output.Missing.IsZero() {
+		t.Fatalf("missing: %s != expected: zero time", output.Missing)
+	}
+
+	encoded, err := json.Marshal(output)
+	if err != nil {
+		t.Fatalf("Marshal failed: %s", err.Error())
+	}
+
+	expectedJSON := `{"created_at":"Wed Oct 10 20:19:24 +0000 2018","end_datetime":"2018-10-11T20:19:24Z","missing":null}`
+	if string(encoded) != expectedJSON {
+		t.Fatalf("encoded: %s != expected: %s", encoded, expectedJSON)
+	}
+}
+
+func TestTimeRejectsUnknownFormats(t *testing.T) {
+	var ts Time
+	err := json.Unmarshal([]byte(`"10/10/2018"`), &ts)
+	if err == nil {
+		t.Fatalf("expected an error for an unknown timestamp format")
+	}
+}
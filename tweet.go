@@ -0,0 +1,55 @@
+package tweetgo
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// WithheldEverywhere is the withheld_in_countries code for content withheld in every country
+	WithheldEverywhere = "XX"
+	// WithheldDMCA is the withheld_in_countries code for content withheld in every country because of a DMCA request
+	WithheldDMCA = "XY"
+)
+
+// IsReply will report whether the tweet is a reply to another tweet
+func (t Tweet) IsReply() bool {
+	return t.InReplyToStatusID != 0
+}
+
+// IsEdited will report whether the tweet has been edited or is an edit of an earlier tweet
+func (t Tweet) IsEdited() bool {
+	return len(t.EditHistory.EditTweetIDs) > 1
+}
+
+// WithheldIn will report whether the tweet must be hidden from viewers in country, a two letter country code. Tweets
+// withheld everywhere, or because of a DMCA request, are withheld in every country.
+// https://help.twitter.com/en/rules-and-policies/tweet-withheld-by-country
+func (t Tweet) WithheldIn(country string) bool {
+	return withheldIn(t.WithheldInCountries, country)
+}
+
+// WithheldIn will report whether the user's profile and tweets must be hidden from viewers in country, a two letter
+// country code
+func (u User) WithheldIn(country string) bool {
+	return withheldIn(u.WithheldInCountries, country)
+}
+
+// EditableUntil will return the time after which the tweet can no longer be edited
+func (c EditControls) EditableUntil() time.Time {
+	if c.EditableUntilMS == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, c.EditableUntilMS*int64(time.Millisecond))
+}
+
+func withheldIn(countries []string, country string) bool {
+	for _, withheld := range countries {
+		if withheld == WithheldEverywhere || withheld == WithheldDMCA || strings.EqualFold(withheld, country) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,78 @@
+package tweetgo
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDecodesAdditionalTweetAttributes(t *testing.T) {
+	output := decodeTestOutput(t, readTestFixture(t, "tweet_withheld.json"))
+
+	if output.CurrentUserRetweet.ID != 1050118621198921999 {
+		t.Fatalf("current_user_retweet: %+v != expected: 1050118621198921999", output.CurrentUserRetweet)
+	}
+
+	if !reflect.DeepEqual(output.WithheldInCountries, []string{"DE", "fr"}) {
+		t.Fatalf("withheld_in_countries: %v != expected: [DE fr]", output.WithheldInCountries)
+	}
+
+	if output.WithheldScope != "status" || output.WithheldCopyright {
+		t.Fatalf("withheld_scope: %s, withheld_copyright: %t != expected: status, false", output.WithheldScope, output.WithheldCopyright)
+	}
+
+	if !output.Scopes.Followers {
+		t.Fatalf("scopes.followers: %t != expected: true", output.Scopes.Followers)
+	}
+
+	if !output.IsReply() || output.InReplyToScreenName != "TwitterAPI" {
+		t.Fatalf("expected the tweet to be a reply to TwitterAPI")
+	}
+
+	for country, expected := range map[string]bool{"DE": true, "FR": true, "de": true, "US": false, "TR": false} {
+		if withheld := output.WithheldIn(country); withheld != expected {
+			t.Errorf("tweet withheld in %s: %t != expected: %t", country, withheld, expected)
+		}
+	}
+
+	if !output.User.WithheldIn("TR") || output.User.WithheldIn("DE") {
+		t.Fatalf("user withheld_in_countries: %v != expected: [TR]", output.User.WithheldInCountries)
+	}
+}
+
+func TestDecodesCopyrightWithheldTweets(t *testing.T) {
+	output := decodeTestOutput(t, readTestFixture(t, "tweet_dmca.json"))
+
+	if !output.WithheldCopyright {
+		t.Fatalf("withheld_copyright: %t != expected: true", output.WithheldCopyright)
+	}
+
+	if !output.WithheldIn("US") || !output.WithheldIn("JP") {
+		t.Fatalf("expected a DMCA withheld tweet to be withheld everywhere")
+	}
+
+	if output.IsReply() {
+		t.Fatalf("expected the tweet not to be a reply")
+	}
+}
+
+func TestDecodesEditHistory(t *testing.T) {
+	output := decodeTestOutput(t, readTestFixture(t, "tweet_edited.json"))
+
+	if output.EditHistory.InitialTweetID != 1577420000000000000 || !output.IsEdited() {
+		t.Fatalf("edit_history: %+v != expected: an edit of 1577420000000000000", output.EditHistory)
+	}
+
+	if output.EditControls.EditsRemaining != 4 || !output.EditControls.IsEditEligible {
+		t.Fatalf("edit_controls: %+v != expected: 4 edits remaining", output.EditControls)
+	}
+
+	expected := time.Date(2022, time.October, 4, 23, 14, 0, 0, time.UTC)
+	if until := output.EditControls.EditableUntil(); !until.Equal(expected) {
+		t.Fatalf("editable until: %s != expected: %s", until, expected)
+	}
+
+	if !reflect.DeepEqual(output.DisplayTextRange, []int{0, 57}) {
+		t.Fatalf("display_text_range: %v != expected: [0 57]", output.DisplayTextRange)
+	}
+}
@@ -0,0 +1,214 @@
+// Package twittertext finds hashtags, cashtags, mentions and urls in text the same way twitter does when a status is
+// posted, following the extraction rules of the twitter-text library and its conformance suite. Indices count unicode
+// code points, like the entities returned by the API.
+// https://github.com/twitter/twitter-text
+package twittertext
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/bloveless/tweetgo"
+)
+
+var (
+	hashtagPattern = regexp.MustCompile(
+		`(?:^|[^&\p{L}\p{M}\p{Nd}_\x{200c}\x{200d}])([#＃])` +
+			`([\p{L}\p{M}\p{Nd}_\x{200c}\x{200d}]*[\p{L}\p{M}][\p{L}\p{M}\p{Nd}_\x{200c}\x{200d}]*)`,
+	)
+	cashtagPattern = regexp.MustCompile(`(?i)(?:^|[\s\p{Z}])(\$)([a-z]{1,6}(?:[._][a-z]{1,2})?)`)
+	mentionPattern = regexp.MustCompile(
+		`(?:^|[^a-zA-Z0-9_!#$%&*@＠]|(?:^|[^a-zA-Z0-9_+~.-])[rR][tT]:?)([@＠])([a-zA-Z0-9_]{1,20})`,
+	)
+	urlPattern = regexp.MustCompile(
+		`(?i)(?:^|[^a-z0-9@＠$#＃\x{202a}-\x{202e}])` +
+			`((https?://)?` +
+			`(?:[a-z0-9\p{Latin}](?:[a-z0-9\p{Latin}_-]*[a-z0-9\p{Latin}])?\.)+([a-z]{2,})` +
+			`(?::[0-9]+)?` +
+			`(/[a-z0-9\p{Latin}\p{Cyrillic}!*';:=+,.$/%#\[\]\x{2013}_~&|@()-]*)?` +
+			`(\?[a-z0-9!?*'();:&=+$/%#\[\]_.,~|@-]*)?)`,
+	)
+)
+
+// Extract will find every entity in text. When entities overlap, such as a hashtag in the fragment of a url, only the
+// one that starts first is kept.
+func Extract(text string) tweetgo.Entities {
+	var found []entity
+	found = append(found, extractURLs(text)...)
+	found = append(found, extractHashtags(text)...)
+	found = append(found, extractMentions(text)...)
+	found = append(found, extractSymbols(text)...)
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].start < found[j].start
+	})
+
+	entities := tweetgo.Entities{}
+	end := 0
+	for _, e := range found {
+		if e.start < end {
+			continue
+		}
+		end = e.end
+
+		indices := []int{runeOffset(text, e.start), runeOffset(text, e.end)}
+		switch e.kind {
+		case kindHashtag:
+			entities.Hashtags = append(entities.Hashtags, tweetgo.Hashtag{Indices: indices, Text: e.text})
+		case kindMention:
+			entities.UserMentions = append(entities.UserMentions, tweetgo.UserMention{Indices: indices, ScreenName: e.text})
+		case kindSymbol:
+			entities.Symbols = append(entities.Symbols, tweetgo.Symbol{Indices: indices, Text: e.text})
+		case kindURL:
+			entities.URLs = append(entities.URLs, tweetgo.EntityURL{Indices: indices, URL: e.text})
+		}
+	}
+
+	return entities
+}
+
+// ExtractHashtags will find every hashtag in text. Text doesn't include the # and hashtags inside urls are ignored.
+func ExtractHashtags(text string) []tweetgo.Hashtag {
+	return Extract(text).Hashtags
+}
+
+// ExtractMentions will find every user mentioned in text. Only ScreenName and Indices are set.
+func ExtractMentions(text string) []tweetgo.UserMention {
+	return Extract(text).UserMentions
+}
+
+// ExtractURLs will find every url in text. Only URL and Indices are set, URL is the link as it appears in text.
+func ExtractURLs(text string) []tweetgo.EntityURL {
+	return Extract(text).URLs
+}
+
+// ExtractSymbols will find every cashtag in text. Text doesn't include the $.
+func ExtractSymbols(text string) []tweetgo.Symbol {
+	return Extract(text).Symbols
+}
+
+type entityKind int
+
+const (
+	kindHashtag entityKind = iota
+	kindMention
+	kindSymbol
+	kindURL
+)
+
+// entity is a match in text, start and end are byte offsets
+type entity struct {
+	kind  entityKind
+	start int
+	end   int
+	text  string
+}
+
+func extractHashtags(text string) []entity {
+	var found []entity
+
+	for _, m := range hashtagPattern.FindAllStringSubmatchIndex(text, -1) {
+		after := text[m[5]:]
+		if strings.HasPrefix(after, "#") || strings.HasPrefix(after, "＃") || strings.HasPrefix(after, "://") {
+			continue
+		}
+
+		found = append(found, entity{kind: kindHashtag, start: m[2], end: m[5], text: text[m[4]:m[5]]})
+	}
+
+	return found
+}
+
+func extractSymbols(text string) []entity {
+	var found []entity
+
+	for _, m := range cashtagPattern.FindAllStringSubmatchIndex(text, -1) {
+		next, _ := utf8.DecodeRuneInString(text[m[5]:])
+		if m[5] < len(text) && !unicode.IsSpace(next) && !unicode.IsPunct(next) {
+			continue
+		}
+
+		found = append(found, entity{kind: kindSymbol, start: m[2], end: m[5], text: text[m[4]:m[5]]})
+	}
+
+	return found
+}
+
+func extractMentions(text string) []entity {
+	var found []entity
+
+	for _, m := range mentionPattern.FindAllStringSubmatchIndex(text, -1) {
+		after := text[m[5]:]
+		next, _ := utf8.DecodeRuneInString(after)
+		if strings.HasPrefix(after, "@") || strings.HasPrefix(after, "＠") || strings.HasPrefix(after, "://") ||
+			unicode.Is(unicode.Latin, next) {
+			continue
+		}
+
+		found = append(found, entity{kind: kindMention, start: m[2], end: m[5], text: text[m[4]:m[5]]})
+	}
+
+	return found
+}
+
+func extractURLs(text string) []entity {
+	var found []entity
+
+	for _, m := range urlPattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := m[2], m[3]
+		hasProtocol := m[4] >= 0
+		hasPath := m[8] >= 0
+		tld := strings.ToLower(text[m[6]:m[7]])
+
+		// the top level domain must end the domain, not just be the start of a longer word
+		if m[7] < len(text) && isDomainContinuation(text[m[7]]) {
+			continue
+		}
+
+		if !hasProtocol && !genericTLDs[tld] && !countryTLDs[tld] {
+			continue
+		}
+
+		// without a protocol or a path a single label on a country code domain, like example.jp, is too likely to be
+		// something else, but www.example.de and example.co.jp are linked
+		if !hasProtocol && !hasPath && !genericTLDs[tld] && strings.Count(text[start:m[7]], ".") < 2 {
+			continue
+		}
+
+		end = start + len(trimURL(text[start:end]))
+		found = append(found, entity{kind: kindURL, start: start, end: end, text: text[start:end]})
+	}
+
+	return found
+}
+
+func isDomainContinuation(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9') || b == '@' || b == '+' || b == '-'
+}
+
+// trimURL will remove punctuation which ends a sentence rather than the url. Closing parentheses are kept when they
+// balance one opened in the url, like in wikipedia links.
+func trimURL(u string) string {
+	for u != "" {
+		last, size := utf8.DecodeLastRuneInString(u)
+		if last == ')' && strings.Count(u, "(") >= strings.Count(u, ")") {
+			break
+		}
+
+		if unicode.IsLetter(last) || unicode.IsDigit(last) || strings.ContainsRune("=_#/-+&", last) {
+			break
+		}
+
+		u = u[:len(u)-size]
+	}
+
+	return u
+}
+
+// runeOffset will convert a byte offset in text into a code point offset
+func runeOffset(text string, offset int) int {
+	return utf8.RuneCountInString(text[:offset])
+}
@@ -0,0 +1,190 @@
+package twittertext
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+// The cases below follow the rules tested by extract.yml in the twitter-text conformance suite
+// https://github.com/twitter/twitter-text/blob/master/conformance/extract.yml
+
+func TestExtractHashtags(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"#hashtag", []string{"hashtag"}},
+		{"text #hashtag", []string{"hashtag"}},
+		{"text #hashtag1 #hashtag2", []string{"hashtag1", "hashtag2"}},
+		{"text #hash_tagged", []string{"hash_tagged"}},
+		{"text #1tag", []string{"1tag"}},
+		{"text #1234", nil},
+		{"text#hashtag", nil},
+		{"text #hashtag#", nil},
+		{"text #hash#tag", nil},
+		{"#ñ #ö #é", []string{"ñ", "ö", "é"}},
+		{"＃ハッシュタグ です", []string{"ハッシュタグ"}},
+		{"#hashtag://", nil},
+		{"&#nbsp;", nil},
+		{"http://example.com/#anchor", nil},
+		{"(#hashtag)", []string{"hashtag"}},
+	}
+
+	for _, test := range tests {
+		var actual []string
+		for _, hashtag := range ExtractHashtags(test.text) {
+			actual = append(actual, hashtag.Text)
+		}
+
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%q hashtags: %v != expected: %v", test.text, actual, test.expected)
+		}
+	}
+}
+
+func TestExtractMentions(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"@username", []string{"username"}},
+		{"hello @username", []string{"username"}},
+		{"@user1 @user2", []string{"user1", "user2"}},
+		{"＠username", []string{"username"}},
+		{"foo@bar.com", nil},
+		{"RT@username", []string{"username"}},
+		{"rt: @username", []string{"username"}},
+		{"@username@", nil},
+		{"@usernameé", nil},
+		{"@username's", []string{"username"}},
+		{"@username: hi", []string{"username"}},
+		{"!@username", nil},
+		{"@usernamewhichismorethan20", nil},
+	}
+
+	for _, test := range tests {
+		var actual []string
+		for _, mention := range ExtractMentions(test.text) {
+			actual = append(actual, mention.ScreenName)
+		}
+
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%q mentions: %v != expected: %v", test.text, actual, test.expected)
+		}
+	}
+}
+
+func TestExtractSymbols(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"$TWTR", []string{"TWTR"}},
+		{"buy $twtr and $goog", []string{"twtr", "goog"}},
+		{"$BRK.A", []string{"BRK.A"}},
+		{"$AAPL, $GOOG.", []string{"AAPL", "GOOG"}},
+		{"$TOOLONGCASHTAG", nil},
+		{"$123", nil},
+		{"ab$TWTR", nil},
+		{"$twtr$", nil},
+	}
+
+	for _, test := range tests {
+		var actual []string
+		for _, symbol := range ExtractSymbols(test.text) {
+			actual = append(actual, symbol.Text)
+		}
+
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%q symbols: %v != expected: %v", test.text, actual, test.expected)
+		}
+	}
+}
+
+func TestExtractURLs(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"http://example.com", []string{"http://example.com"}},
+		{"visit https://www.example.com/path?query=1&b=2.", []string{"https://www.example.com/path?query=1&b=2"}},
+		{"example.com", []string{"example.com"}},
+		{"www.example.com/foo", []string{"www.example.com/foo"}},
+		{"t.co/abc", []string{"t.co/abc"}},
+		{"example.co", nil},
+		{"http://example.co", []string{"http://example.co"}},
+		{"(http://example.com)", []string{"http://example.com"}},
+		{"http://en.wikipedia.org/wiki/Madonna_(artist)", []string{"http://en.wikipedia.org/wiki/Madonna_(artist)"}},
+		{"see example.com, example.org!", []string{"example.com", "example.org"}},
+		{"user@example.com", nil},
+		{"$example.com", nil},
+		{"いまなにしてるwww.example.comいまなにしてる", []string{"www.example.com"}},
+		{"http://example.com:8080/path", []string{"http://example.com:8080/path"}},
+		{"example.community", nil},
+		{"www.example.de", []string{"www.example.de"}},
+		{"example.co.jp", []string{"example.co.jp"}},
+		{"example.jp", nil},
+	}
+
+	for _, test := range tests {
+		var actual []string
+		for _, u := range ExtractURLs(test.text) {
+			actual = append(actual, u.URL)
+		}
+
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%q urls: %v != expected: %v", test.text, actual, test.expected)
+		}
+	}
+}
+
+// TestExtractURLsConformance runs the url cases vendored from extract.yml into testdata/extract_urls.json
+func TestExtractURLsConformance(t *testing.T) {
+	raw, err := ioutil.ReadFile("testdata/extract_urls.json")
+	if err != nil {
+		t.Fatalf("could not read conformance cases: %s", err.Error())
+	}
+
+	var tests []struct {
+		Description string   `json:"description"`
+		Text        string   `json:"text"`
+		Expected    []string `json:"expected"`
+	}
+	err = json.Unmarshal(raw, &tests)
+	if err != nil {
+		t.Fatalf("could not decode conformance cases: %s", err.Error())
+	}
+
+	for _, test := range tests {
+		actual := []string{}
+		for _, u := range ExtractURLs(test.Text) {
+			actual = append(actual, u.URL)
+		}
+
+		if !reflect.DeepEqual(actual, test.Expected) {
+			t.Errorf("%s: %v != expected: %v", test.Description, actual, test.Expected)
+		}
+	}
+}
+
+func TestExtractIndicesCountCodePoints(t *testing.T) {
+	entities := Extract("😀 @gopher #go $GO https://golang.org")
+
+	if indices := entities.UserMentions[0].Indices; !reflect.DeepEqual(indices, []int{2, 9}) {
+		t.Fatalf("mention indices: %v != expected: [2 9]", indices)
+	}
+
+	if indices := entities.Hashtags[0].Indices; !reflect.DeepEqual(indices, []int{10, 13}) {
+		t.Fatalf("hashtag indices: %v != expected: [10 13]", indices)
+	}
+
+	if indices := entities.Symbols[0].Indices; !reflect.DeepEqual(indices, []int{14, 17}) {
+		t.Fatalf("symbol indices: %v != expected: [14 17]", indices)
+	}
+
+	if indices := entities.URLs[0].Indices; !reflect.DeepEqual(indices, []int{18, 36}) {
+		t.Fatalf("url indices: %v != expected: [18 36]", indices)
+	}
+}
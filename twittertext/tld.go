@@ -0,0 +1,30 @@
+package twittertext
+
+import "strings"
+
+// genericTLDs are the generic top level domains which are linked without a protocol
+var genericTLDs = toSet(`
+aero app art asia biz blog cat cloud club com coop design dev edu email gov info int jobs live mil mobi museum name net
+news online org page photo photos pro shop site space store tech tel travel vip website wiki work world xxx xyz
+`)
+
+// countryTLDs are the country code top level domains, which are only linked without a protocol when followed by a path
+// or when the domain has more than one label before them
+var countryTLDs = toSet(`
+ac ad ae af ag ai al am an ao aq ar as at au aw ax az ba bb bd be bf bg bh bi bj bl bm bn bo bq br bs bt bv bw by bz
+ca cc cd cf cg ch ci ck cl cm cn co cr cs cu cv cw cx cy cz dd de dj dk dm do dz ec ee eg eh er es et eu fi fj fk fm fo
+fr ga gb gd ge gf gg gh gi gl gm gn gp gq gr gs gt gu gw gy hk hm hn hr ht hu id ie il im in io iq ir is it je jm jo jp
+ke kg kh ki km kn kp kr kw ky kz la lb lc li lk lr ls lt lu lv ly ma mc md me mf mg mh mk ml mm mn mo mp mq mr ms mt mu
+mv mw mx my mz na nc ne nf ng ni nl no np nr nu nz om pa pe pf pg ph pk pl pm pn pr ps pt pw py qa re ro rs ru rw sa sb
+sc sd se sg sh si sj sk sl sm sn so sr ss st su sv sx sy sz tc td tf tg th tj tk tl tm tn to tp tr tt tv tw tz ua ug uk
+um us uy uz va vc ve vg vi vn vu wf ws ye yt za zm zw
+`)
+
+func toSet(list string) map[string]bool {
+	set := map[string]bool{}
+	for _, tld := range strings.Fields(list) {
+		set[tld] = true
+	}
+
+	return set
+}
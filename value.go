@@ -16,6 +16,10 @@ func Int64(input int64) *int64 {
 	return &input
 }
 
+func IDPtr(input ID) *ID {
+	return &input
+}
+
 func Float64(input float64) *float64 {
 	return &input
 }